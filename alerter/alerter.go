@@ -1,8 +1,14 @@
 package alerter
 
+import (
+	"context"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
 // Telegram to send telegram alert interface
 type Telegram interface {
-	SendTelegramMessage(msgText, botToken string, chatID int64) error
+	SendTelegramMessage(ctx context.Context, msgText, botToken string, chatID int64) error
 }
 
 type telegramAlert struct{}
@@ -14,7 +20,7 @@ func NewTelegramAlerter() *telegramAlert {
 
 // Email to send mail alert
 type Email interface {
-	SendEmail(msg, token, toEmail string) error
+	SendEmail(ctx context.Context, msg string, cfg *config.Config) error
 }
 
 type emailAlert struct{}
@@ -26,7 +32,7 @@ func NewEmailAlerter() *emailAlert {
 
 // Slack to send slack alert
 type Slack interface {
-	SendSlackMessage(msg, webhookURL string) error
+	SendSlackMessage(ctx context.Context, msg, ackEvent, webhookURL, webhookSecret string) error
 }
 
 type slackAlert struct{}
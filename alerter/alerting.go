@@ -1,18 +1,237 @@
 package alerter
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
 
 	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+const (
+	// Info alerts are notifications which need no action, e.g. startup or new epoch
+	Info = "info"
+	// Warning alerts need attention but are not urgent, e.g. skip rate or block difference
+	Warning = "warning"
+	// Critical alerts need immediate action, e.g. delinquent validator or node down
+	Critical = "critical"
 )
 
+// Events which trigger alerts, used to label the alerts sent
+const (
+	EventStartup             = "startup"
+	EventShutdown            = "shutdown"
+	EventNodeDown            = "node_down"
+	EventSkipRate            = "skip_rate"
+	EventBalance             = "balance"
+	EventDelegation          = "delegation"
+	EventUndelegation        = "undelegation"
+	EventValidatorStatus     = "validator_status"
+	EventDelinquent          = "delinquent"
+	EventActiveSet           = "active_set"
+	EventVoteLatency         = "vote_latency"
+	EventNewEpoch            = "new_epoch"
+	EventEpochDifference     = "epoch_diff"
+	EventBlockDifference     = "block_diff"
+	EventMinorityFork        = "minority_fork"
+	EventVoteCredits         = "vote_credits"
+	EventPossibleRestart     = "possible_restart"
+	EventVoteCost            = "vote_cost"
+	EventBlockProduction     = "block_production"
+	EventDeactivatingStake   = "deactivating_stake"
+	EventIdentityConflict    = "identity_conflict"
+	EventCreditsRank         = "vote_credits_rank"
+	EventRPCParse            = "rpc_parse"
+	EventSlotsBehind         = "slots_behind"
+	EventRentMargin          = "rent_margin"
+	EventPortUnreachable     = "port_unreachable"
+	EventTPUForwarding       = "tpu_forwarding"
+	EventDelinquencyForecast = "delinquency_forecast"
+	EventNextLeaderSchedule  = "next_leader_schedule"
+	EventVoteKeyMismatch     = "vote_key_mismatch"
+	EventRPCRateLimit        = "rpc_rate_limit"
+	EventVersionUpgrade      = "version_upgrade"
+	EventRPCEndpoints        = "rpc_endpoints"
+	EventCatchup             = "catchup"
+	EventFinalizationLag     = "finalization_lag"
+	EventAlertRule           = "alert_rule"
+	EventMissingFeatures     = "missing_features"
+)
+
+// SendAlert sends the alert of the given event to all the enabled channels concurrently, so that a slow
+// channel doesn't delay the others. Critical alerts are prefixed with the configured mentions of each
+// channel so that they notify someone. Nothing is sent while alerts are snoozed or the event is acknowledged,
+// and info and warning alerts raised during quiet hours are queued and sent as a digest afterwards.
+func SendAlert(event, msg, severity string, cfg *config.Config) error {
+	if until := SnoozedUntil(); !until.IsZero() {
+		log.Printf("Alerts are snoozed until %s, not sending alert : %s", until.Format(time.RFC3339), msg)
+		return nil
+	}
+	if until := AcknowledgedUntil(event); !until.IsZero() {
+		log.Printf("Alerts of event %s are acknowledged until %s, not sending alert : %s", event, until.Format(time.RFC3339), msg)
+		return nil
+	}
+
+	alertsSent.WithLabelValues(event, severity).Inc()
+
+	if link := dashboardLink(event, cfg); link != "" {
+		msg = msg + "\n" + link
+	}
+
+	if severity != Critical && inQuietHours(cfg.QuietHours, time.Now()) {
+		log.Printf("Quiet hours, deferring alert to the digest : %s", msg)
+		deferAlert(msg, severity)
+		return nil
+	}
+
+	tgMsg, slackMsg := msg, msg
+	if severity == Critical {
+		tgMsg = telegramMentions(cfg.AlertMentions.Telegram) + msg
+		slackMsg = slackMentions(cfg.AlertMentions.Slack) + msg
+	}
+
+	return dispatch(event, tgMsg, msg, slackMsg, cfg)
+}
+
+// alertChannel is an alert channel and the function sending the message to it
+type alertChannel struct {
+	name string
+	send func() error
+}
+
+// dispatch sends the given message of each channel to all the enabled channels concurrently,
+// wrapped in the configured prefix and suffix. Slack alerts of the given event can be acknowledged,
+// no event is given for messages like the digest. Failover channels are only sent to when sending
+// to one of the other channels fails. An error is only returned when no channel delivered the alert,
+// failures of single channels are logged otherwise.
+func dispatch(event, tgMsg, msg, slackMsg string, cfg *config.Config) error {
+	tgMsg, msg, slackMsg = wrapMessage(tgMsg, cfg), wrapMessage(msg, cfg), wrapMessage(slackMsg, cfg)
+
+	// only enabled channels are sent to, so that a disabled channel doesn't count as delivered
+	send := map[string]func() error{}
+	if cfg.EnableAlerts.EnableTelegramAlerts {
+		send["telegram"] = func() error { return SendTelegramAlert(tgMsg, cfg) }
+	}
+	if cfg.EnableAlerts.EnableEmailAlerts {
+		send["email"] = func() error { return SendEmailAlert(msg, cfg) }
+	}
+	if cfg.EnableAlerts.EnableSlackAlerts {
+		send["slack"] = func() error { return SendSlackAlert(slackMsg, event, cfg) }
+	}
+	var channels, failover []alertChannel
+	for _, name := range cfg.EnableAlerts.FailoverChannels {
+		if fn, ok := send[name]; ok {
+			failover = append(failover, alertChannel{name: name, send: fn})
+			delete(send, name)
+		}
+	}
+	for _, name := range []string{"telegram", "email", "slack"} {
+		if fn, ok := send[name]; ok {
+			channels = append(channels, alertChannel{name: name, send: fn})
+		}
+	}
+
+	// errors of every channel are kept to report all of them, not only the first one
+	channelErrs := make([]error, len(channels))
+	var g errgroup.Group
+	for i, ch := range channels {
+		i, ch := i, ch
+		g.Go(func() error {
+			channelErrs[i] = ch.send()
+			return channelErrs[i]
+		})
+	}
+	_ = g.Wait()
+
+	var errs []string
+	delivered := false
+	for i, err := range channelErrs {
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", channels[i].name, err))
+			continue
+		}
+		delivered = true
+	}
+
+	// the failover channels are tried one after the other until one delivers the alert
+	if len(errs) != 0 {
+		for _, ch := range failover {
+			log.Printf("Sending alert to failover channel %s", ch.name)
+			err := ch.send()
+			if err == nil {
+				delivered = true
+				break
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", ch.name, err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	if delivered {
+		log.Printf("Error while sending alert to some channels : %s", strings.Join(errs, "; "))
+		return nil
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
+
+// wrapMessage returns the message with the configured prefix and suffix e.g. an environment tag
+func wrapMessage(msg string, cfg *config.Config) string {
+	if p := cfg.AlertFormat.Prefix; p != "" {
+		msg = p + " " + msg
+	}
+	if s := cfg.AlertFormat.Suffix; s != "" {
+		msg = msg + "\n" + s
+	}
+	return msg
+}
+
+// defaultAmountDecimals is the number of digits after the decimal point of amounts in SOL if not configured
+const defaultAmountDecimals = 4
+
+// FormatAmount formats the amount in lamports for alert messages in the configured unit, precision and
+// thousands separator e.g. 1,234.5678 SOL
+func FormatAmount(lamports float64, cfg *config.Config) string {
+	decimals := defaultAmountDecimals
+	if cfg.AlertFormat.AmountDecimals != nil {
+		decimals = *cfg.AlertFormat.AmountDecimals
+	}
+	return utils.FormatLamports(lamports, cfg.AlertFormat.AmountUnit, decimals, cfg.AlertFormat.ThousandsSeparator)
+}
+
+// telegramMentions returns the mention string of the given telegram usernames
+func telegramMentions(usernames []string) string {
+	var mentions string
+	for _, u := range usernames {
+		mentions = mentions + "@" + strings.TrimPrefix(u, "@") + " "
+	}
+	return mentions
+}
+
+// slackMentions returns the mention string of the given slack member IDs
+func slackMentions(userIDs []string) string {
+	var mentions string
+	for _, id := range userIDs {
+		mentions = mentions + "<@" + id + "> "
+	}
+	return mentions
+}
+
 // SendTelegramAlert sends the alert to telegram account
 // check's alert setting before sending the alert
 func SendTelegramAlert(msg string, cfg *config.Config) error {
 	if strings.ToUpper(strconv.FormatBool(cfg.EnableAlerts.EnableTelegramAlerts)) == "TRUE" {
-		if err := NewTelegramAlerter().SendTelegramMessage(msg, cfg.Telegram.BotToken, cfg.Telegram.ChatID); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout(cfg.Telegram.SendTimeout))
+		defer cancel()
+		if err := NewTelegramAlerter().SendTelegramMessage(ctx, msg, cfg.Telegram.BotToken, cfg.Telegram.ChatID); err != nil {
 			log.Printf("Failed to send tg alert : %v of msg : %s", err, msg)
 			return err
 		}
@@ -24,7 +243,9 @@ func SendTelegramAlert(msg string, cfg *config.Config) error {
 // by checking user's choice
 func SendEmailAlert(msg string, cfg *config.Config) error {
 	if strings.ToUpper(strconv.FormatBool(cfg.EnableAlerts.EnableEmailAlerts)) == "TRUE" {
-		if err := NewEmailAlerter().SendEmail(msg, cfg); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout(cfg.SendGrid.SendTimeout))
+		defer cancel()
+		if err := NewEmailAlerter().SendEmail(ctx, msg, cfg); err != nil {
 			log.Printf("failed to send email alert: %v", err)
 			return err
 		}
@@ -32,10 +253,17 @@ func SendEmailAlert(msg string, cfg *config.Config) error {
 	return nil
 }
 
-// SendSlackAlert sends alert to a slack webhook
-func SendSlackAlert(msg string, cfg *config.Config) error {
+// SendSlackAlert sends alert to a slack webhook, with a button to acknowledge the given event if the signing
+// secret is configured and the event is not empty
+func SendSlackAlert(msg, event string, cfg *config.Config) error {
 	if strings.ToUpper(strconv.FormatBool(cfg.EnableAlerts.EnableSlackAlerts)) == "TRUE" {
-		if err := NewSlackAlerter().SendSlackMessage(msg, cfg.Slack.WebhookURL); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout(cfg.Slack.SendTimeout))
+		defer cancel()
+		ackEvent := ""
+		if slackAckEnabled(cfg) {
+			ackEvent = event
+		}
+		if err := NewSlackAlerter().SendSlackMessage(ctx, msg, ackEvent, cfg.Slack.WebhookURL, cfg.Slack.WebhookSecret); err != nil {
 			log.Printf("failed to send email alert: %v", err)
 			return err
 		}
@@ -0,0 +1,385 @@
+package alerter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// newTestSlackServer starts a slack webhook which records the text of every received message
+func newTestSlackServer(t *testing.T) (*httptest.Server, *[]string) {
+	var texts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		texts = append(texts, payload["text"])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &texts
+}
+
+// newTestSlackConfig returns a config with only slack alerts enabled
+func newTestSlackConfig(webhookURL string) *config.Config {
+	return &config.Config{
+		EnableAlerts: config.EnableAlerts{EnableSlackAlerts: true},
+		Slack:        config.Slack{WebhookURL: webhookURL},
+	}
+}
+
+func TestSendAlertMentions(t *testing.T) {
+	srv, texts := newTestSlackServer(t)
+
+	cfg := newTestSlackConfig(srv.URL)
+	cfg.AlertMentions.Slack = []string{"U012AB3CD"}
+
+	if err := SendAlert(EventDelinquent, "Your solana validator is in DELINQUENT state", Critical, cfg); err != nil {
+		t.Fatal("Error while sending critical alert :", err)
+	}
+	if err := SendAlert(EventNewEpoch, "New epoch started 100 -> 101", Info, cfg); err != nil {
+		t.Fatal("Error while sending info alert :", err)
+	}
+
+	if len(*texts) != 2 {
+		t.Fatalf("Expected 2 slack messages, got %d", len(*texts))
+	}
+	if !strings.Contains((*texts)[0], "<@U012AB3CD>") {
+		t.Error("Expected mention in critical alert, got: ", (*texts)[0])
+	}
+	if strings.Contains((*texts)[1], "<@U012AB3CD>") {
+		t.Error("Expected no mention in info alert, got: ", (*texts)[1])
+	}
+}
+
+func TestTelegramMentions(t *testing.T) {
+	if got := telegramMentions([]string{"@oncall", "backup"}); got != "@oncall @backup " {
+		t.Errorf("Expected telegram mentions %q, got %q", "@oncall @backup ", got)
+	}
+}
+
+// telegramTransport sends the requests to telegram api to the given test server instead
+type telegramTransport struct {
+	target *url.URL
+}
+
+func (t telegramTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == "api.telegram.org" {
+		req.URL.Scheme = t.target.Scheme
+		req.URL.Host = t.target.Host
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSendAlertHangingChannel(t *testing.T) {
+	// slack webhook which never answers
+	done := make(chan struct{})
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slack.Close()
+	defer close(done)
+
+	var delivered []string
+	tg := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"bot","username":"bot"}}`))
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			delivered = append(delivered, r.FormValue("text"))
+			w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1,"type":"private"},"date":0,"text":"ok"}}`))
+		}
+	}))
+	defer tg.Close()
+
+	tgURL, _ := url.Parse(tg.URL)
+	transport = telegramTransport{target: tgURL}
+	defer func() { transport = http.DefaultTransport }()
+
+	cfg := &config.Config{
+		EnableAlerts: config.EnableAlerts{EnableTelegramAlerts: true, EnableSlackAlerts: true},
+		Telegram:     config.Telegram{BotToken: "token", ChatID: 1, SendTimeout: "5s"},
+		Slack:        config.Slack{WebhookURL: slack.URL, SendTimeout: "200ms"},
+	}
+
+	start := time.Now()
+	err := SendAlert(EventNodeDown, "Your node is not running", Critical, cfg)
+	elapsed := time.Since(start)
+
+	// the slack timeout is only logged as the alert was delivered to telegram
+	if err != nil {
+		t.Errorf("Expected no error once telegram delivered the alert, got %v", err)
+	}
+	if len(delivered) != 1 {
+		t.Errorf("Expected 1 telegram message, got %d", len(delivered))
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("Expected alert to return after the slack timeout, took %v", elapsed)
+	}
+}
+
+func TestSendAlertQuietHours(t *testing.T) {
+	srv, texts := newTestSlackServer(t)
+
+	// quiet hours around now
+	now := time.Now().UTC()
+	cfg := newTestSlackConfig(srv.URL)
+	cfg.QuietHours = config.QuietHours{
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		End:      now.Add(time.Hour).Format("15:04"),
+		Timezone: "UTC",
+	}
+
+	if err := SendAlert(EventNewEpoch, "New epoch started 100 -> 101", Info, cfg); err != nil {
+		t.Fatal("Error while sending info alert :", err)
+	}
+	if len(*texts) != 0 {
+		t.Fatalf("Expected info alert to be deferred during quiet hours, got %v", *texts)
+	}
+
+	if err := SendAlert(EventDelinquent, "Your solana validator is in DELINQUENT state", Critical, cfg); err != nil {
+		t.Fatal("Error while sending critical alert :", err)
+	}
+	if len(*texts) != 1 || !strings.Contains((*texts)[0], "DELINQUENT") {
+		t.Fatalf("Expected critical alert to be sent during quiet hours, got %v", *texts)
+	}
+
+	// digest is held until quiet hours are over
+	if err := FlushDigest(cfg); err != nil {
+		t.Fatal("Error while sending digest :", err)
+	}
+	if len(*texts) != 1 {
+		t.Fatalf("Expected no digest during quiet hours, got %v", *texts)
+	}
+
+	cfg.QuietHours = config.QuietHours{}
+	if err := FlushDigest(cfg); err != nil {
+		t.Fatal("Error while sending digest :", err)
+	}
+	if len(*texts) != 2 || !strings.Contains((*texts)[1], "New epoch started 100 -> 101") {
+		t.Errorf("Expected digest with the deferred alert, got %v", *texts)
+	}
+}
+
+func TestFlushDigest(t *testing.T) {
+	digestMu.Lock()
+	digest = nil
+	digestMu.Unlock()
+
+	// an alert raised on every check and more distinct alerts than are listed
+	for i := 0; i < 1000; i++ {
+		deferAlert("Block Difference Alert : Block difference b/w network and validator has exceeded 30", Warning)
+	}
+	for i := 0; i < maxDigestEntries+2; i++ {
+		deferAlert(fmt.Sprintf("New epoch started %d -> %d", 100+i, 101+i), Info)
+	}
+
+	// the digest is kept when sending it fails
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	if err := FlushDigest(newTestSlackConfig(down.URL)); err == nil {
+		t.Fatal("Expected error while sending digest to an unreachable channel, got nil")
+	}
+
+	srv, texts := newTestSlackServer(t)
+	cfg := newTestSlackConfig(srv.URL)
+	if err := FlushDigest(cfg); err != nil {
+		t.Fatal("Error while sending digest :", err)
+	}
+	if len(*texts) != 1 {
+		t.Fatalf("Expected 1 digest, got %d", len(*texts))
+	}
+	msg := (*texts)[0]
+	for _, want := range []string{
+		fmt.Sprintf("%d alerts during quiet hours", 1000+maxDigestEntries+2),
+		"[warning] Block Difference Alert : Block difference b/w network and validator has exceeded 30 (x1000)",
+		"... and 3 more",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected digest to contain %q, got %q", want, msg)
+		}
+	}
+	if len(msg) > 4096 {
+		t.Errorf("Expected digest within 4096 characters, got %d", len(msg))
+	}
+
+	// the digest is emptied once sent
+	if err := FlushDigest(cfg); err != nil {
+		t.Fatal("Error while sending digest :", err)
+	}
+	if len(*texts) != 1 {
+		t.Errorf("Expected no digest after it was sent, got %v", *texts)
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	qh := config.QuietHours{Start: "22:00", End: "08:00", Timezone: "UTC"}
+
+	for _, tc := range []struct {
+		at    string
+		quiet bool
+	}{
+		{"21:59", false},
+		{"22:00", true},
+		{"03:00", true},
+		{"08:00", false},
+		{"12:00", false},
+	} {
+		at, _ := time.Parse("15:04", tc.at)
+		if got := inQuietHours(qh, at); got != tc.quiet {
+			t.Errorf("Expected quiet hours at %s to be %v, got %v", tc.at, tc.quiet, got)
+		}
+	}
+}
+
+func TestSendAlertPrefixSuffix(t *testing.T) {
+	srv, texts := newTestSlackServer(t)
+
+	cfg := newTestSlackConfig(srv.URL)
+	cfg.AlertMentions.Slack = []string{"U012AB3CD"}
+	cfg.AlertFormat = config.AlertFormat{Prefix: "[MAINNET-PROD]", Suffix: "-- host-1"}
+
+	if err := SendAlert(EventDelinquent, "Your solana validator is in DELINQUENT state", Critical, cfg); err != nil {
+		t.Fatal("Error while sending alert :", err)
+	}
+
+	expected := "[MAINNET-PROD] <@U012AB3CD> Your solana validator is in DELINQUENT state\n-- host-1"
+	if len(*texts) != 1 || (*texts)[0] != expected {
+		t.Errorf("Expected slack message %q, got %q", expected, *texts)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	cfg := &config.Config{}
+	if got := FormatAmount(1234567890000, cfg); got != "1234.5679 SOL" {
+		t.Errorf("Expected amount with default precision, got %q", got)
+	}
+
+	decimals := 2
+	cfg.AlertFormat = config.AlertFormat{AmountDecimals: &decimals, ThousandsSeparator: ","}
+	if got := FormatAmount(1234567890000, cfg); got != "1,234.57 SOL" {
+		t.Errorf("Expected amount with configured precision and separator, got %q", got)
+	}
+
+	decimals = 0
+	if got := FormatAmount(1234567890000, cfg); got != "1,235 SOL" {
+		t.Errorf("Expected amount without decimals, got %q", got)
+	}
+}
+
+func TestSendAlertDashboardLink(t *testing.T) {
+	srv, texts := newTestSlackServer(t)
+
+	cfg := newTestSlackConfig(srv.URL)
+	cfg.ValDetails.ValidatorName = "my val"
+	cfg.AlertFormat.DashboardBaseURL = "https://grafana.example.com/d/_lBG68yGz/validator-monitoring-metrics?orgId=1"
+	cfg.AlertFormat.DashboardLinkEvents = []string{EventDelinquent}
+
+	if err := SendAlert(EventDelinquent, "Your solana validator is in DELINQUENT state", Warning, cfg); err != nil {
+		t.Fatal("Error while sending alert :", err)
+	}
+	if err := SendAlert(EventNewEpoch, "New epoch started 100 -> 101", Info, cfg); err != nil {
+		t.Fatal("Error while sending alert :", err)
+	}
+	if len(*texts) != 2 {
+		t.Fatalf("Expected 2 slack messages, got %v", *texts)
+	}
+
+	lines := strings.Split((*texts)[0], "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected dashboard link on its own line, got %q", (*texts)[0])
+	}
+	link, err := url.Parse(lines[1])
+	if err != nil || link.Host != "grafana.example.com" || link.Path != "/d/_lBG68yGz/validator-monitoring-metrics" {
+		t.Fatalf("Expected link to the dashboard, got %q (%v)", lines[1], err)
+	}
+	for param, expected := range map[string]string{
+		"orgId":         "1",
+		"var-validator": "my val",
+		"from":          "now-1h",
+		"to":            "now",
+		"viewPanel":     "59",
+	} {
+		if got := link.Query().Get(param); got != expected {
+			t.Errorf("Expected %s=%q in dashboard link, got %q", param, expected, got)
+		}
+	}
+
+	if strings.Contains((*texts)[1], "grafana.example.com") {
+		t.Errorf("Expected no dashboard link in alert of unselected event, got %q", (*texts)[1])
+	}
+}
+
+// redirectTransport sends all requests to the given test server instead
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSendAlertFailoverChannel(t *testing.T) {
+	telegramUp, emailUp := true, true
+	var emails int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v3/mail/send" && !emailUp:
+			panic(http.ErrAbortHandler)
+		case r.URL.Path == "/v3/mail/send":
+			emails++
+			w.WriteHeader(http.StatusAccepted)
+		case !telegramUp:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"bot","username":"bot"}}`))
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1,"type":"private"},"date":0,"text":"ok"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	transport = redirectTransport{target: target}
+	defer func() { transport = http.DefaultTransport }()
+
+	cfg := &config.Config{
+		EnableAlerts: config.EnableAlerts{EnableTelegramAlerts: true, EnableEmailAlerts: true, FailoverChannels: []string{"email"}},
+		Telegram:     config.Telegram{BotToken: "token", ChatID: 1},
+		SendGrid:     config.SendGrid{Token: "token", SendgridEmail: "monitor@example.com", ReceiverEmailAddress: "oncall@example.com"},
+	}
+
+	if err := SendAlert(EventNodeDown, "Your node is not running", Critical, cfg); err != nil {
+		t.Fatal("Error while sending alert :", err)
+	}
+	if emails != 0 {
+		t.Errorf("Expected no failover email while telegram works, got %d", emails)
+	}
+
+	telegramUp = false
+	if err := SendAlert(EventNodeDown, "Your node is not running", Critical, cfg); err != nil {
+		t.Errorf("Expected no error once the failover channel delivered the alert, got %v", err)
+	}
+	if emails != 1 {
+		t.Errorf("Expected failover email after telegram failed, got %d", emails)
+	}
+
+	emailUp = false
+	err := SendAlert(EventNodeDown, "Your node is not running", Critical, cfg)
+	if err == nil || !strings.Contains(err.Error(), "telegram") || !strings.Contains(err.Error(), "email") {
+		t.Errorf("Expected telegram and email errors when no channel delivered the alert, got %v", err)
+	}
+}
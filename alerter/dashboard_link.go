@@ -0,0 +1,76 @@
+package alerter
+
+import (
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// dashboardPanels are the panels of the bundled validator monitoring dashboard linked from the alerts of each
+// event, alerts of other events link to the whole dashboard
+var dashboardPanels = map[string]int{
+	EventNodeDown:            20,
+	EventSlotsBehind:         20,
+	EventSkipRate:            74,
+	EventBalance:             2,
+	EventDelegation:          36,
+	EventUndelegation:        36,
+	EventValidatorStatus:     59,
+	EventDelinquent:          59,
+	EventActiveSet:           36,
+	EventVoteLatency:         68,
+	EventEpochDifference:     57,
+	EventBlockDifference:     65,
+	EventMinorityFork:        40,
+	EventVoteCredits:         71,
+	EventPossibleRestart:     71,
+	EventBlockProduction:     77,
+	EventDeactivatingStake:   36,
+	EventIdentityConflict:    38,
+	EventCreditsRank:         71,
+	EventRentMargin:          2,
+	EventPortUnreachable:     20,
+	EventTPUForwarding:       20,
+	EventDelinquencyForecast: 59,
+	EventNextLeaderSchedule:  77,
+	EventVoteKeyMismatch:     38,
+}
+
+// dashboardLink returns the link to the dashboard panel of the given event for the validator over the last hour,
+// empty if no dashboard is configured or the event is not selected to include it
+func dashboardLink(event string, cfg *config.Config) string {
+	base := cfg.AlertFormat.DashboardBaseURL
+	if base == "" || !dashboardLinkSelected(event, cfg.AlertFormat.DashboardLinkEvents) {
+		return ""
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		log.Printf("Invalid dashboard base url %q, not linking it in alerts: %v", base, err)
+		return ""
+	}
+	q := u.Query()
+	q.Set("var-validator", cfg.ValDetails.ValidatorName)
+	q.Set("from", "now-1h")
+	q.Set("to", "now")
+	if panel, ok := dashboardPanels[event]; ok {
+		q.Set("viewPanel", strconv.Itoa(panel))
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// dashboardLinkSelected reports whether alerts of the event include the dashboard link, all do if none are selected
+func dashboardLinkSelected(event string, events []string) bool {
+	if len(events) == 0 {
+		return true
+	}
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
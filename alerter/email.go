@@ -1,6 +1,10 @@
 package alerter
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/sendgrid/rest"
 	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
 
@@ -8,7 +12,7 @@ import (
 )
 
 // SendEmail to send mail alert
-func (e emailAlert) SendEmail(msg string, cfg *config.Config) error {
+func (e emailAlert) SendEmail(ctx context.Context, msg string, cfg *config.Config) error {
 	accountName := cfg.SendGrid.SendgridName
 	fromEmail := cfg.SendGrid.SendgridEmail
 	toEmail := cfg.SendGrid.ReceiverEmailAddress
@@ -20,10 +24,20 @@ func (e emailAlert) SendEmail(msg string, cfg *config.Config) error {
 	plainTextContent := msg
 	htmlContent := msg
 	message := mail.NewSingleEmail(from, subject, to, plainTextContent, htmlContent)
-	client := sendgrid.NewSendClient(token)
-	_, err := client.Send(message)
+
+	request := sendgrid.GetRequest(token, "/v3/mail/send", "https://api.sendgrid.com")
+	request.Method = rest.Post
+	request.Body = mail.GetRequestBody(message)
+	req, err := rest.BuildRequestObject(request)
+	if err != nil {
+		return err
+	}
+
+	client := &rest.Client{HTTPClient: &http.Client{Transport: transport}}
+	res, err := client.MakeRequest(req.WithContext(ctx))
 	if err != nil {
 		return err
 	}
-	return nil
+	_, err = rest.BuildResponse(res) // reads and closes the response body
+	return err
 }
@@ -0,0 +1,89 @@
+package alerter
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// escalation is an ongoing critical condition whose alert is re-sent until it clears
+type escalation struct {
+	raisedAt time.Time
+	sentAt   time.Time
+	repeats  int
+}
+
+// escalations are the ongoing critical conditions by event
+var (
+	escalationMu sync.Mutex
+	escalations  = make(map[string]*escalation)
+)
+
+// Escalating reports whether the critical condition of the given event is being escalated
+func Escalating(event string) bool {
+	escalationMu.Lock()
+	defer escalationMu.Unlock()
+	_, ok := escalations[event]
+	return ok
+}
+
+// Escalate tracks the critical condition of the given event after its alert has been sent, it is to be called on
+// every check of the condition. While the condition persists the alert is re-sent at the configured escalation
+// intervals, the last interval repeating until it clears, and a recovery note is sent once it clears. Nothing is
+// tracked without escalation intervals.
+func Escalate(event, msg string, active bool, cfg *config.Config) error {
+	return escalate(event, msg, active, cfg, time.Now())
+}
+
+func escalate(event, msg string, active bool, cfg *config.Config, now time.Time) error {
+	intervals := escalationIntervals(cfg)
+
+	escalationMu.Lock()
+	e, ok := escalations[event]
+	var severity string
+	switch {
+	case active && !ok:
+		if len(intervals) > 0 {
+			escalations[event] = &escalation{raisedAt: now, sentAt: now}
+		}
+	case active:
+		// the last interval repeats once all of them have passed
+		i := e.repeats
+		if i >= len(intervals) {
+			i = len(intervals) - 1
+		}
+		if i >= 0 && now.Sub(e.sentAt) >= intervals[i] {
+			e.sentAt = now
+			e.repeats++
+			severity = Critical
+			msg = fmt.Sprintf("%s (still ongoing after %s, reminder %d)", msg, now.Sub(e.raisedAt).Round(time.Second), e.repeats)
+		}
+	case ok:
+		delete(escalations, event)
+		severity = Info
+		msg = fmt.Sprintf("Recovered : The %s alert is acknowledged by recovery after %s", event, now.Sub(e.raisedAt).Round(time.Second))
+	}
+	escalationMu.Unlock()
+
+	if severity == "" {
+		return nil
+	}
+	return SendAlert(event, msg, severity, cfg)
+}
+
+// escalationIntervals returns the configured escalation intervals, invalid ones are skipped
+func escalationIntervals(cfg *config.Config) []time.Duration {
+	var intervals []time.Duration
+	for _, i := range cfg.AlerterPreferences.EscalationIntervals {
+		d, err := time.ParseDuration(i)
+		if err != nil || d <= 0 {
+			log.Printf("Invalid escalation interval %q, skipping it: %v", i, err)
+			continue
+		}
+		intervals = append(intervals, d)
+	}
+	return intervals
+}
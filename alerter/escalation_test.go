@@ -0,0 +1,71 @@
+package alerter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEscalate(t *testing.T) {
+	srv, texts := newTestSlackServer(t)
+	cfg := newTestSlackConfig(srv.URL)
+	cfg.AlerterPreferences.EscalationIntervals = []string{"5m", "15m"}
+	defer delete(escalations, EventDelinquent)
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	msg := "Your solana validator is in DELINQUENT state"
+
+	for _, step := range []struct {
+		after  time.Duration
+		active bool
+		sent   int
+	}{
+		// the first alert is sent by the caller, escalation starts tracking
+		{0, true, 0},
+		{4 * time.Minute, true, 0},
+		{5 * time.Minute, true, 1},
+		{15 * time.Minute, true, 1},
+		{20 * time.Minute, true, 2},
+		// the last interval repeats
+		{35 * time.Minute, true, 3},
+		{40 * time.Minute, true, 3},
+		// recovery note once the condition clears, then nothing more
+		{41 * time.Minute, false, 4},
+		{60 * time.Minute, false, 4},
+	} {
+		if err := escalate(EventDelinquent, msg, step.active, cfg, start.Add(step.after)); err != nil {
+			t.Fatal("Error while escalating alert :", err)
+		}
+		if len(*texts) != step.sent {
+			t.Fatalf("Expected %d messages after %s, got %v", step.sent, step.after, *texts)
+		}
+	}
+
+	if !strings.Contains((*texts)[0], "reminder 1") || !strings.Contains((*texts)[2], "reminder 3") {
+		t.Errorf("Expected numbered reminders, got %v", *texts)
+	}
+	if !strings.Contains((*texts)[3], "acknowledged by recovery after 41m0s") {
+		t.Errorf("Expected recovery note, got %q", (*texts)[3])
+	}
+	if Escalating(EventDelinquent) {
+		t.Error("Expected escalation to stop after recovery")
+	}
+}
+
+func TestEscalateWithoutIntervals(t *testing.T) {
+	srv, texts := newTestSlackServer(t)
+	cfg := newTestSlackConfig(srv.URL)
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Duration{0, time.Hour, 24 * time.Hour} {
+		if err := escalate(EventNodeDown, "Your node is not running", true, cfg, start.Add(at)); err != nil {
+			t.Fatal("Error while escalating alert :", err)
+		}
+	}
+	if err := escalate(EventNodeDown, "", false, cfg, start.Add(25*time.Hour)); err != nil {
+		t.Fatal("Error while escalating alert :", err)
+	}
+	if len(*texts) != 0 || Escalating(EventNodeDown) {
+		t.Errorf("Expected no escalation without intervals, got %v", *texts)
+	}
+}
@@ -0,0 +1,60 @@
+package alerter
+
+import (
+	"fmt"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// The built-in alert messages which are translated, as english format strings. The catalog is keyed by these
+// constants, so that changing an english message doesn't silently drop its translations.
+const (
+	MsgNotVoting         = "Solana validator is NOT VOTING"
+	MsgVoting            = "Solana validator is VOTING"
+	MsgDelinquent        = "Your solana validator is in DELINQUENT state since %s"
+	MsgNodeDown          = "Your node is not running"
+	MsgNewEpoch          = "New epoch started %d -> %d"
+	MsgNewActivatedStake = ", new activated stake: %.4f"
+	MsgBlockDifference   = "Block Difference Alert : Block difference b/w network and validator has exceeded %d"
+	MsgEpochDifference   = "Epoch Difference Alert : Difference b/w network and validator epoch is %d, has reached the configured threshold %d"
+	MsgActiveSet         = "Active Set Alert : Your solana validator has no activated stake and has fallen out of the active set"
+	MsgSkipRate          = "SKIP RATE ALERT ::  Your validator SKIP RATE : %f has exceeded network SKIP RATE : %f"
+)
+
+// catalog holds the translations of the built-in alert messages by locale, keyed by their english format.
+// The translations must keep the verbs of the english format in the same order.
+var catalog = map[string]map[string]string{
+	"de": {
+		MsgNotVoting:         "Solana Validator STIMMT NICHT AB",
+		MsgVoting:            "Solana Validator STIMMT AB",
+		MsgDelinquent:        "Ihr Solana Validator ist seit %s im Status DELINQUENT",
+		MsgNodeDown:          "Ihr Node läuft nicht",
+		MsgNewEpoch:          "Neue Epoche gestartet %d -> %d",
+		MsgNewActivatedStake: ", neuer aktivierter Stake: %.4f",
+		MsgBlockDifference:   "Blockdifferenz-Alarm : Die Blockdifferenz zwischen Netzwerk und Validator hat %d überschritten",
+		MsgEpochDifference:   "Epochendifferenz-Alarm : Die Differenz zwischen Netzwerk- und Validator-Epoche beträgt %d und hat den konfigurierten Schwellenwert %d erreicht",
+		MsgActiveSet:         "Active-Set-Alarm : Ihr Solana Validator hat keinen aktivierten Stake und ist aus dem Active Set gefallen",
+		MsgSkipRate:          "SKIP-RATE-ALARM ::  Die SKIP RATE Ihres Validators : %f hat die SKIP RATE des Netzwerks überschritten : %f",
+	},
+	"es": {
+		MsgNotVoting:         "El validador de Solana NO ESTÁ VOTANDO",
+		MsgVoting:            "El validador de Solana ESTÁ VOTANDO",
+		MsgDelinquent:        "Su validador de Solana está en estado DELINQUENT desde hace %s",
+		MsgNodeDown:          "Su nodo no está en ejecución",
+		MsgNewEpoch:          "Nueva época iniciada %d -> %d",
+		MsgNewActivatedStake: ", nuevo stake activado: %.4f",
+		MsgBlockDifference:   "Alerta de diferencia de bloques : La diferencia de bloques entre la red y el validador ha superado %d",
+		MsgEpochDifference:   "Alerta de diferencia de épocas : La diferencia entre la época de la red y del validador es %d, ha alcanzado el umbral configurado %d",
+		MsgActiveSet:         "Alerta de active set : Su validador de Solana no tiene stake activado y ha salido del active set",
+		MsgSkipRate:          "ALERTA DE SKIP RATE ::  El SKIP RATE de su validador : %f ha superado el SKIP RATE de la red : %f",
+	},
+}
+
+// Localize formats the built-in alert message of the given english format in the configured locale, falling back
+// to english when the locale is en, not configured or has no translation of the message
+func Localize(cfg *config.Config, format string, args ...interface{}) string {
+	if translated, ok := catalog[cfg.AlertFormat.Locale][format]; ok {
+		format = translated
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,70 @@
+package alerter
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestLocalize(t *testing.T) {
+	for _, tc := range []struct {
+		locale   string
+		format   string
+		expected string
+	}{
+		{"", MsgNewEpoch, "New epoch started 100 -> 101"},
+		{"en", MsgNewEpoch, "New epoch started 100 -> 101"},
+		{"de", MsgNewEpoch, "Neue Epoche gestartet 100 -> 101"},
+		{"es", MsgNewEpoch, "Nueva época iniciada 100 -> 101"},
+		// messages without a translation fall back to english
+		{"de", "Untranslated epoch %d -> %d", "Untranslated epoch 100 -> 101"},
+	} {
+		cfg := &config.Config{AlertFormat: config.AlertFormat{Locale: tc.locale}}
+		if got := Localize(cfg, tc.format, 100, 101); got != tc.expected {
+			t.Errorf("Expected %q in locale %q, got %q", tc.expected, tc.locale, got)
+		}
+	}
+}
+
+func TestLocalizeDelinquency(t *testing.T) {
+	for locale, expected := range map[string]string{
+		"en": "Your solana validator is in DELINQUENT state since 5m0s",
+		"de": "Ihr Solana Validator ist seit 5m0s im Status DELINQUENT",
+		"es": "Su validador de Solana está en estado DELINQUENT desde hace 5m0s",
+	} {
+		cfg := &config.Config{AlertFormat: config.AlertFormat{Locale: locale}}
+		if got := Localize(cfg, MsgDelinquent, "5m0s"); got != expected {
+			t.Errorf("Expected %q in locale %q, got %q", expected, locale, got)
+		}
+	}
+}
+
+var verbRegexp = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+func TestCatalog(t *testing.T) {
+	// every locale accepted by the config besides english has a catalog
+	field, _ := reflect.TypeOf(config.AlertFormat{}).FieldByName("Locale")
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if !strings.HasPrefix(rule, "oneof=") {
+			continue
+		}
+		for _, locale := range strings.Fields(strings.TrimPrefix(rule, "oneof=")) {
+			if _, ok := catalog[locale]; !ok && locale != "en" {
+				t.Errorf("Expected a catalog of locale %q, got none", locale)
+			}
+		}
+	}
+
+	// translations keep the verbs of the english format
+	for locale, messages := range catalog {
+		for format, translated := range messages {
+			expected, got := verbRegexp.FindAllString(format, -1), verbRegexp.FindAllString(translated, -1)
+			if !reflect.DeepEqual(expected, got) {
+				t.Errorf("Expected verbs %v in %s translation %q, got %v", expected, locale, translated, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package alerter
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var alertsSent = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "solana_alerts_sent_total",
+		Help: "Number of alerts sent, grouped by event and severity",
+	},
+	[]string{"event", "severity"})
+
+// RegisterMetrics registers the metrics of the alerter with the given registerer
+func RegisterMetrics(r prometheus.Registerer) {
+	r.MustRegister(alertsSent)
+}
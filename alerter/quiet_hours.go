@@ -0,0 +1,149 @@
+package alerter
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// maxDigestEntries is the number of distinct alerts listed in the digest, the rest are counted in a last line
+// so that the message stays within the size limits of the channels e.g. 4096 characters on telegram
+const maxDigestEntries = 20
+
+// digestEntry is an alert queued during quiet hours and how often it was raised
+type digestEntry struct {
+	msg   string
+	count int
+}
+
+// info and warning alerts raised during quiet hours are queued here and sent as a digest afterwards
+var (
+	digestMu sync.Mutex
+	digest   []digestEntry
+)
+
+// inQuietHours reports whether t falls in the configured quiet hours. Quiet hours may wrap around midnight
+// e.g. 22:00 to 08:00, they are disabled when start or end is not set or invalid.
+func inQuietHours(qh config.QuietHours, t time.Time) bool {
+	if qh.Start == "" || qh.End == "" {
+		return false
+	}
+
+	loc := time.Local
+	if qh.Timezone != "" {
+		l, err := time.LoadLocation(qh.Timezone)
+		if err != nil {
+			log.Printf("Invalid quiet hours timezone %q : %v", qh.Timezone, err)
+			return false
+		}
+		loc = l
+	}
+
+	start, err := time.Parse("15:04", qh.Start)
+	if err != nil {
+		log.Printf("Invalid quiet hours start %q : %v", qh.Start, err)
+		return false
+	}
+	end, err := time.Parse("15:04", qh.End)
+	if err != nil {
+		log.Printf("Invalid quiet hours end %q : %v", qh.End, err)
+		return false
+	}
+
+	t = t.In(loc)
+	now := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from <= to {
+		return now >= from && now < to
+	}
+	return now >= from || now < to
+}
+
+// deferAlert queues the alert to be sent in the digest after quiet hours, an alert raised again e.g. on every
+// check only counts up its entry
+func deferAlert(msg, severity string) {
+	digestMu.Lock()
+	defer digestMu.Unlock()
+	msg = fmt.Sprintf("[%s] %s", severity, msg)
+	for i := range digest {
+		if digest[i].msg == msg {
+			digest[i].count++
+			return
+		}
+	}
+	digest = append(digest, digestEntry{msg: msg, count: 1})
+}
+
+// digestMessage returns the digest of the given alerts, listing at most maxDigestEntries of them
+func digestMessage(alerts []digestEntry) string {
+	total := 0
+	lines := make([]string, 0, maxDigestEntries+1)
+	for i, a := range alerts {
+		total += a.count
+		if i >= maxDigestEntries {
+			continue
+		}
+		if a.count > 1 {
+			lines = append(lines, fmt.Sprintf("%s (x%d)", a.msg, a.count))
+		} else {
+			lines = append(lines, a.msg)
+		}
+	}
+	if n := len(alerts) - maxDigestEntries; n > 0 {
+		lines = append(lines, fmt.Sprintf("... and %d more", n))
+	}
+	return fmt.Sprintf("%d alerts during quiet hours:\n%s", total, strings.Join(lines, "\n"))
+}
+
+// FlushDigest sends the alerts queued during quiet hours as a single message once quiet hours are over.
+// Nothing is sent while alerts are snoozed, the digest is kept until the snooze ends. The digest is also
+// kept when sending it fails, to be sent again on the next flush.
+func FlushDigest(cfg *config.Config) error {
+	if inQuietHours(cfg.QuietHours, time.Now()) || !SnoozedUntil().IsZero() {
+		return nil
+	}
+
+	digestMu.Lock()
+	alerts := append([]digestEntry(nil), digest...)
+	digestMu.Unlock()
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	msg := digestMessage(alerts)
+	if err := dispatch("", msg, msg, msg, cfg); err != nil {
+		return err
+	}
+
+	// alerts queued while sending are kept for the next digest, entries are only appended in the meantime
+	digestMu.Lock()
+	defer digestMu.Unlock()
+	var rest []digestEntry
+	for i, e := range digest {
+		if i < len(alerts) {
+			e.count -= alerts[i].count
+		}
+		if e.count > 0 {
+			rest = append(rest, e)
+		}
+	}
+	digest = rest
+	return nil
+}
+
+// WatchQuietHours sends the digest of alerts queued during quiet hours every minute once they are over
+func WatchQuietHours(cfg *config.Config) {
+	for {
+		if err := FlushDigest(cfg); err != nil {
+			log.Printf("Error while sending quiet hours digest : %v", err)
+		}
+		time.Sleep(time.Minute)
+	}
+}
@@ -2,21 +2,32 @@ package alerter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
-func (s *slackAlert) SendSlackMessage(msg, webhookURL string) error {
-	data := map[string]string{
+func (s *slackAlert) SendSlackMessage(ctx context.Context, msg, ackEvent, webhookURL, webhookSecret string) error {
+	data := map[string]interface{}{
 		"text": msg,
 	}
+	if ackEvent != "" {
+		data["blocks"] = slackAckBlocks(msg, ackEvent)
+	}
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 
-	resp, err := http.Post(webhookURL, "application/json", bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	signWebhook(req, payload, webhookSecret)
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
 	if err != nil {
 		return err
 	}
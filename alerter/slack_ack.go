@@ -0,0 +1,164 @@
+package alerter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+const (
+	// SlackInteractionsPath is the path on the metrics server at which slack posts the interactions of alert buttons
+	SlackInteractionsPath = "/slack/interactions"
+	// ackActionID identifies the acknowledge button of slack alerts
+	ackActionID = "acknowledge"
+	// defaultAckWindow is how long alerts of an acknowledged event are suppressed if not configured
+	defaultAckWindow = time.Hour
+	// maxSlackRequestAge is how old the timestamp of a slack request may be, older requests are rejected as replays
+	maxSlackRequestAge = 5 * time.Minute
+)
+
+// alerts of an event are not sent until its time in acknowledged, set through the acknowledge button of slack alerts
+var (
+	ackMu        sync.Mutex
+	acknowledged = make(map[string]time.Time)
+)
+
+// Acknowledge suppresses the alerts of the given event for the given duration
+func Acknowledge(event string, d time.Duration) {
+	ackMu.Lock()
+	defer ackMu.Unlock()
+	acknowledged[event] = time.Now().Add(d)
+}
+
+// AcknowledgedUntil returns the time until which alerts of the given event are suppressed, zero time if the event
+// is not acknowledged
+func AcknowledgedUntil(event string) time.Time {
+	ackMu.Lock()
+	defer ackMu.Unlock()
+	until := acknowledged[event]
+	if time.Now().After(until) {
+		return time.Time{}
+	}
+	return until
+}
+
+// slackAckEnabled returns whether slack alerts carry an acknowledge button, which needs the signing secret
+// to verify the interactions
+func slackAckEnabled(cfg *config.Config) bool {
+	return cfg.Slack.SigningSecret != ""
+}
+
+// ackWindow parses the configured duration alerts of an acknowledged event are suppressed, defaults to 1h
+func ackWindow(cfg *config.Config) time.Duration {
+	d, err := time.ParseDuration(cfg.Slack.AckWindow)
+	if err != nil || d <= 0 {
+		return defaultAckWindow
+	}
+	return d
+}
+
+// slackInteraction is the part of the block actions payload slack posts when a button is clicked
+type slackInteraction struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// SlackInteractionHandler returns the handler of the interactions of slack alerts. Requests are verified with
+// the configured signing secret, a click on the acknowledge button suppresses the alerts of its event for the
+// configured window.
+func SlackInteractionHandler(cfg *config.Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+		if !verifySlackSignature(cfg.Slack.SigningSecret, r.Header.Get("X-Slack-Request-Timestamp"),
+			r.Header.Get("X-Slack-Signature"), body, time.Now()) {
+			log.Printf("Rejected slack interaction with invalid signature")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+		var interaction slackInteraction
+		if err := json.Unmarshal([]byte(form.Get("payload")), &interaction); err != nil {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+
+		window := ackWindow(cfg)
+		for _, action := range interaction.Actions {
+			if action.ActionID != ackActionID || action.Value == "" {
+				continue
+			}
+			Acknowledge(action.Value, window)
+			log.Printf("Alerts of event %s acknowledged by %s for %s", action.Value, interaction.User.Username, window)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// verifySlackSignature returns whether the given signature of a slack request matches the request body signed
+// with the signing secret, and its timestamp is recent so that it can't be replayed
+func verifySlackSignature(secret, timestamp, signature string, body []byte, now time.Time) bool {
+	if secret == "" {
+		return false
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || math.Abs(float64(now.Unix()-ts)) > maxSlackRequestAge.Seconds() {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// slackAckBlocks returns the blocks of a slack alert with the given text and a button to acknowledge its event
+func slackAckBlocks(msg, event string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": msg},
+		},
+		map[string]interface{}{
+			"type": "actions",
+			"elements": []interface{}{
+				map[string]interface{}{
+					"type":      "button",
+					"action_id": ackActionID,
+					"text":      map[string]string{"type": "plain_text", "text": "Acknowledge"},
+					"value":     event,
+				},
+			},
+		},
+	}
+}
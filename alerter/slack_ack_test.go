@@ -0,0 +1,78 @@
+package alerter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// signedSlackRequest returns an interaction request of slack with the given payload signed with the given secret
+func signedSlackRequest(secret, payload string, at time.Time) *http.Request {
+	body := url.Values{"payload": {payload}}.Encode()
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + ts + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, SlackInteractionsPath, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestSlackInteractionAcknowledge(t *testing.T) {
+	cfg := &config.Config{Slack: config.Slack{SigningSecret: "8f742231b10e8888abcd99yyyzzz85a5", AckWindow: "30m"}}
+	handler := SlackInteractionHandler(cfg)
+	payload := `{"type":"block_actions","user":{"id":"U012AB3CD","username":"oncall"},"actions":[{"action_id":"acknowledge","value":"` + EventVoteLatency + `"}]}`
+
+	// a request signed with another secret is rejected
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedSlackRequest("other secret", payload, time.Now()))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for invalid signature, got %d", http.StatusUnauthorized, rec.Code)
+	}
+	// as is a replayed request
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedSlackRequest(cfg.Slack.SigningSecret, payload, time.Now().Add(-time.Hour)))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for an old request, got %d", http.StatusUnauthorized, rec.Code)
+	}
+	if until := AcknowledgedUntil(EventVoteLatency); !until.IsZero() {
+		t.Fatalf("Expected event not to be acknowledged by rejected requests, got %v", until)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedSlackRequest(cfg.Slack.SigningSecret, payload, time.Now()))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	until := AcknowledgedUntil(EventVoteLatency)
+	if d := time.Until(until); d <= 29*time.Minute || d > 30*time.Minute {
+		t.Errorf("Expected event to be acknowledged for 30m, got until %v", until)
+	}
+	defer Acknowledge(EventVoteLatency, 0)
+
+	// alerts of the acknowledged event are suppressed, others are still sent
+	srv, texts := newTestSlackServer(t)
+	cfg.EnableAlerts.EnableSlackAlerts = true
+	cfg.Slack.WebhookURL = srv.URL
+	cfg.Slack.SigningSecret = ""
+	if err := SendAlert(EventVoteLatency, "Vote latency exceeded", Warning, cfg); err != nil {
+		t.Fatal("Error while sending alert :", err)
+	}
+	if err := SendAlert(EventNodeDown, "Your node is not running", Critical, cfg); err != nil {
+		t.Fatal("Error while sending alert :", err)
+	}
+	if len(*texts) != 1 || !strings.Contains((*texts)[0], "not running") {
+		t.Errorf("Expected only the alert of the unacknowledged event, got %v", *texts)
+	}
+}
@@ -0,0 +1,29 @@
+package alerter
+
+import (
+	"sync"
+	"time"
+)
+
+// alerts are not sent until snoozedUntil, set through the telegram /snooze command
+var (
+	snoozeMu     sync.Mutex
+	snoozedUntil time.Time
+)
+
+// Snooze suppresses all alerts for the given duration, a duration of 0 resumes alerting right away
+func Snooze(d time.Duration) {
+	snoozeMu.Lock()
+	defer snoozeMu.Unlock()
+	snoozedUntil = time.Now().Add(d)
+}
+
+// SnoozedUntil returns the time until which alerts are snoozed, zero time if they are not snoozed
+func SnoozedUntil() time.Time {
+	snoozeMu.Lock()
+	defer snoozeMu.Unlock()
+	if time.Now().After(snoozedUntil) {
+		return time.Time{}
+	}
+	return snoozedUntil
+}
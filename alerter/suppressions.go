@@ -0,0 +1,61 @@
+package alerter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// suppressionsCollector exports which alert suppressions are currently active, so that operators can tell why
+// alerts are not firing
+type suppressionsCollector struct {
+	cfg  *config.Config
+	desc *prometheus.Desc
+}
+
+// NewSuppressionsCollector returns a collector of the alert suppressions active at scrape time, i.e. whether alerts
+// are snoozed, whether it is quiet hours and the number of acknowledged events
+func NewSuppressionsCollector(cfg *config.Config) prometheus.Collector {
+	return &suppressionsCollector{
+		cfg: cfg,
+		desc: prometheus.NewDesc(
+			"solana_active_suppressions",
+			"Number of active alert suppressions by category, i.e. snooze, quiet_hours or acknowledged events",
+			[]string{"category"}, nil,
+		),
+	}
+}
+
+// Describe exports the description of the suppressions metric
+func (c *suppressionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect exports the number of active suppressions of each category
+func (c *suppressionsCollector) Collect(ch chan<- prometheus.Metric) {
+	for category, n := range activeSuppressions(c.cfg, time.Now()) {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(n), category)
+	}
+}
+
+// activeSuppressions returns the number of suppressions of each category active at the given time
+func activeSuppressions(cfg *config.Config, now time.Time) map[string]int {
+	suppressions := map[string]int{"snooze": 0, "quiet_hours": 0, "acknowledged": 0}
+	if !SnoozedUntil().IsZero() {
+		suppressions["snooze"] = 1
+	}
+	if inQuietHours(cfg.QuietHours, now) {
+		suppressions["quiet_hours"] = 1
+	}
+
+	ackMu.Lock()
+	defer ackMu.Unlock()
+	for _, until := range acknowledged {
+		if now.Before(until) {
+			suppressions["acknowledged"]++
+		}
+	}
+	return suppressions
+}
@@ -0,0 +1,35 @@
+package alerter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestActiveSuppressions(t *testing.T) {
+	now := time.Now()
+	cfg := &config.Config{}
+
+	if got := activeSuppressions(cfg, now); got["snooze"] != 0 || got["quiet_hours"] != 0 || got["acknowledged"] != 0 {
+		t.Fatalf("Expected no active suppressions, got %v", got)
+	}
+
+	// alerts are snoozed for maintenance, it is quiet hours and two events are acknowledged
+	Snooze(time.Hour)
+	defer Snooze(0)
+	Acknowledge(EventSkipRate, time.Hour)
+	Acknowledge(EventVoteCredits, time.Hour)
+	defer Acknowledge(EventSkipRate, 0)
+	defer Acknowledge(EventVoteCredits, 0)
+	cfg.QuietHours = config.QuietHours{
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		End:      now.Add(time.Hour).Format("15:04"),
+		Timezone: "Local",
+	}
+
+	got := activeSuppressions(cfg, now)
+	if got["snooze"] != 1 || got["quiet_hours"] != 1 || got["acknowledged"] != 2 {
+		t.Errorf("Expected snooze, quiet hours and 2 acknowledged events, got %v", got)
+	}
+}
@@ -1,10 +1,15 @@
 package alerter
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+import (
+	"context"
+	"net/http"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
 
 // SendTelegramMessage to send alert to telegram bot
-func (t telegramAlert) SendTelegramMessage(msgText, botToken string, chatID int64) error {
-	bot, err := tgbotapi.NewBotAPI(botToken)
+func (t telegramAlert) SendTelegramMessage(ctx context.Context, msgText, botToken string, chatID int64) error {
+	bot, err := tgbotapi.NewBotAPIWithClient(botToken, &http.Client{Transport: contextTransport{ctx: ctx}})
 	if err != nil {
 		return err
 	}
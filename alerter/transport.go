@@ -0,0 +1,33 @@
+package alerter
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// defaultSendTimeout is the timeout of sending an alert to a channel if not configured
+const defaultSendTimeout = 10 * time.Second
+
+// transport is used by the http clients of all alert channels
+var transport http.RoundTripper = http.DefaultTransport
+
+// contextTransport attaches its context to every request, so that clients which don't
+// accept a context, e.g. the telegram bot api, are still bounded by the send timeout
+type contextTransport struct {
+	ctx context.Context
+}
+
+// RoundTrip executes the request with the context of the transport
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return transport.RoundTrip(req.WithContext(t.ctx))
+}
+
+// sendTimeout returns the given send timeout of a channel, defaultSendTimeout if not configured or invalid
+func sendTimeout(timeout string) time.Duration {
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		return defaultSendTimeout
+	}
+	return d
+}
@@ -0,0 +1,27 @@
+package alerter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// WebhookSignatureHeader is the header of outbound webhook alerts which carries the hmac of their body, so that
+// the receiving service can verify that alerts come from this instance
+const WebhookSignatureHeader = "X-Signature-256"
+
+// webhookSignature returns the signature of the given body, the hex encoded HMAC-SHA256 with the secret prefixed by sha256=
+func webhookSignature(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// signWebhook sets the signature of the given body on the request, requests are not signed without a secret
+func signWebhook(req *http.Request, body []byte, secret string) {
+	if secret == "" {
+		return
+	}
+	req.Header.Set(WebhookSignatureHeader, webhookSignature(body, secret))
+}
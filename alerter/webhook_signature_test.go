@@ -0,0 +1,42 @@
+package alerter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendSlackAlertSigned(t *testing.T) {
+	var bodies [][]byte
+	var signatures []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, body)
+		signatures = append(signatures, r.Header.Get(WebhookSignatureHeader))
+	}))
+	defer srv.Close()
+
+	cfg := newTestSlackConfig(srv.URL)
+	if err := SendSlackAlert("unsigned", EventSkipRate, cfg); err != nil {
+		t.Fatal("Error while sending slack alert :", err)
+	}
+	cfg.Slack.WebhookSecret = "webhook-secret"
+	if err := SendSlackAlert("signed", EventSkipRate, cfg); err != nil {
+		t.Fatal("Error while sending slack alert :", err)
+	}
+
+	if len(signatures) != 2 || signatures[0] != "" {
+		t.Fatalf("Expected only the alert with a secret to be signed, got %q", signatures)
+	}
+	// the receiver verifies the signature with the shared secret
+	mac := hmac.New(sha256.New, []byte("webhook-secret"))
+	mac.Write(bodies[1])
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signatures[1]), []byte(expected)) {
+		t.Errorf("Expected signature %s of the body, got %s", expected, signatures[1])
+	}
+}
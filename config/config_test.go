@@ -0,0 +1,273 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRPCSources(t *testing.T) {
+	cfg := &Config{Endpoints: Endpoints{RPCSources: map[string]string{"vote_accounts": "network"}}}
+
+	if got := cfg.RPCSource("vote_accounts", "validator"); got != "network" {
+		t.Errorf("Expected configured rpc source network, got %s", got)
+	}
+	if got := cfg.RPCSource("current_slot", "validator"); got != "validator" {
+		t.Errorf("Expected default rpc source validator, got %s", got)
+	}
+
+	cfg.Endpoints.RPCSources["current_slot"] = "public"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for unknown rpc source")
+	}
+}
+
+func TestValidateStaticLabels(t *testing.T) {
+	cfg := &Config{Prometheus: Prometheus{StaticLabels: map[string]string{"region": "eu", "operator_name": "op"}}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid static labels, got %v", err)
+	}
+
+	for _, name := range []string{"data-center", "1region", "__name", "version", "epoch"} {
+		cfg.Prometheus.StaticLabels = map[string]string{name: "x"}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Expected validation error for static label name %q", name)
+		}
+	}
+}
+
+func TestValidateLocale(t *testing.T) {
+	for _, locale := range []string{"", "en", "de", "es"} {
+		cfg := &Config{AlertFormat: AlertFormat{Locale: locale}}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected locale %q to be valid, got %v", locale, err)
+		}
+	}
+
+	// an unknown locale is rejected instead of falling back to english
+	for _, locale := range []string{"fr", "DE", "de_DE"} {
+		cfg := &Config{AlertFormat: AlertFormat{Locale: locale}}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Expected validation error for locale %q", locale)
+		}
+	}
+}
+
+func TestMetricLabels(t *testing.T) {
+	cfg := &Config{
+		Prometheus: Prometheus{StaticLabels: map[string]string{"region": "eu"}},
+		ValDetails: ValDetails{ValidatorName: "my-val"},
+	}
+	if labels := cfg.MetricLabels(); len(labels) != 1 || labels["region"] != "eu" {
+		t.Errorf("Expected only static labels, got %v", labels)
+	}
+
+	cfg.Prometheus.ValidatorLabel = true
+	if labels := cfg.MetricLabels(); len(labels) != 2 || labels["validator"] != "my-val" {
+		t.Errorf("Expected validator label, got %v", labels)
+	}
+
+	cfg.Prometheus.StaticLabels["validator"] = "other"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for static label clashing with validator_label")
+	}
+}
+
+func TestValidateRequired(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cfg     Config
+		missing []string
+	}{
+		{
+			name:    "telegram",
+			cfg:     Config{EnableAlerts: EnableAlerts{EnableTelegramAlerts: true}},
+			missing: []string{"telegram.tg_bot_token", "telegram.tg_chat_id"},
+		},
+		{
+			name: "telegram configured",
+			cfg:  Config{EnableAlerts: EnableAlerts{EnableTelegramAlerts: true}, Telegram: Telegram{BotToken: "token", ChatID: 1}},
+		},
+		{
+			name:    "email",
+			cfg:     Config{EnableAlerts: EnableAlerts{EnableEmailAlerts: true}, SendGrid: SendGrid{Token: "token"}},
+			missing: []string{"sendgrid.receiver_email_address", "sendgrid.account_email"},
+		},
+		{
+			name:    "slack",
+			cfg:     Config{EnableAlerts: EnableAlerts{EnableSlackAlerts: true}},
+			missing: []string{"slack.webhook_url"},
+		},
+		{
+			name: "slack disabled",
+			cfg:  Config{Slack: Slack{SendTimeout: "10s"}},
+		},
+		{
+			name:    "thresholds",
+			cfg:     Config{AlerterPreferences: AlerterPreferences{BlockDiffAlerts: "yes", AccountBalanceChangeAlerts: "yes", EpochDiffAlerts: "yes"}, AlertingThresholds: AlertingThreshold{BalanceChangeThresholdSOL: 1}},
+			missing: []string{"alerting_threholds.block_diff_threshold"},
+		},
+	} {
+		err := tc.cfg.Validate()
+		if len(tc.missing) == 0 {
+			if err != nil {
+				t.Errorf("%s: expected valid config, got %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected missing %v, got no error", tc.name, tc.missing)
+			continue
+		}
+		for _, field := range tc.missing {
+			if !strings.Contains(err.Error(), field) {
+				t.Errorf("%s: expected %s to be reported missing, got %v", tc.name, field, err)
+			}
+		}
+	}
+}
+
+func TestValidateNegativeThreshold(t *testing.T) {
+	cfg := Config{AlertingThresholds: AlertingThreshold{VoteCostThresholdSOL: -1}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "VoteCostThresholdSOL") {
+		t.Errorf("Expected negative threshold to be invalid, got %v", err)
+	}
+}
+
+func TestApplyRenamedKeys(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	err := v.ReadConfig(strings.NewReader(`[validator_details]
+pub_key_path = "/home/sol/validator-keypair.json"
+
+[alerting_threholds]
+balance_change_threshold = 2.5
+vote_cost_threshold = 1
+vote_cost_threshold_sol = 3
+`))
+	if err != nil {
+		t.Fatal("Error while reading config :", err)
+	}
+	applyRenamedKeys(v)
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatal("Error while unmarshaling config :", err)
+	}
+	if got := cfg.AlertingThresholds.BalanceChangeThresholdSOL; got != 2.5 {
+		t.Errorf("Expected balance threshold of the deprecated key, got %v", got)
+	}
+	if got := cfg.AlertingThresholds.VoteCostThresholdSOL; got != 3 {
+		t.Errorf("Expected vote cost threshold of the new key, got %v", got)
+	}
+	if got := cfg.ValDetails.PubKeyPath; got != "/home/sol/validator-keypair.json" {
+		t.Errorf("Expected identity keypair path of the deprecated key, got %q", got)
+	}
+}
+
+func TestMergeAlertsFile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	alertsPath := filepath.Join(dir, "alerts.toml")
+	files := map[string]string{
+		configPath: `[rpc_and_lcd_endpoints]
+rpc_endpoint = "http://localhost:8899"
+
+[alerting_threholds]
+skip_rate_threshold = 5
+block_diff_threshold = 20
+balance_change_threshold_sol = 1
+
+[telegram]
+tg_chat_id = 1
+tg_bot_token = "main-token"
+`,
+		alertsPath: `[alerting_threholds]
+skip_rate_threshold = 10
+balance_change_threshold = 2.5
+
+[telegram]
+tg_bot_token = "alerts-token"
+
+[rpc_and_lcd_endpoints]
+rpc_endpoint = "http://ignored:8899"
+`,
+	}
+	for p, content := range files {
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal("Error while reading config :", err)
+	}
+	if err := mergeAlertsFile(v, alertsPath); err != nil {
+		t.Fatal("Error while merging alerts config :", err)
+	}
+	applyRenamedKeys(v)
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatal("Error while unmarshaling config :", err)
+	}
+	// keys of the alerts file override config.toml, the ones it doesn't set are kept
+	if got := cfg.AlertingThresholds.SkipRateThreshold; got != 10 {
+		t.Errorf("Expected skip rate threshold of the alerts file, got %v", got)
+	}
+	if got := cfg.AlertingThresholds.BlockDiffThreshold; got != 20 {
+		t.Errorf("Expected block diff threshold of config.toml, got %v", got)
+	}
+	if got := cfg.AlertingThresholds.BalanceChangeThresholdSOL; got != 2.5 {
+		t.Errorf("Expected renamed balance threshold of the alerts file, got %v", got)
+	}
+	if cfg.Telegram.BotToken != "alerts-token" || cfg.Telegram.ChatID != 1 {
+		t.Errorf("Expected bot token of the alerts file and chat id of config.toml, got %+v", cfg.Telegram)
+	}
+	// only alerting sections can be set in the alerts file
+	if got := cfg.Endpoints.RPCEndpoint; got != "http://localhost:8899" {
+		t.Errorf("Expected rpc endpoint of config.toml, got %s", got)
+	}
+
+	if err := mergeAlertsFile(v, filepath.Join(dir, "missing.toml")); err == nil {
+		t.Error("Expected an error for a missing alerts file")
+	}
+}
+
+func TestReadUpgradeFeatures(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	err := v.ReadConfig(strings.NewReader(`[upgrade]
+required_version = "1.16.20"
+
+[[upgrade.features]]
+id = "7Vced912WrRnfjaiKRiNBcbuFw7RrnLv3E3z95Y4GTNc"
+min_version = "1.16.20"
+
+[[upgrade.features]]
+id = "4RWNif6C2WCNiKVW7otP4G7dkmkHGyKQWRpuZ1pxKU5m"
+min_version = "1.17.0"
+`))
+	if err != nil {
+		t.Fatal("Error while reading config :", err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatal("Error while unmarshaling config :", err)
+	}
+	// feature ids are case sensitive base58 addresses
+	expected := []Feature{
+		{ID: "7Vced912WrRnfjaiKRiNBcbuFw7RrnLv3E3z95Y4GTNc", MinVersion: "1.16.20"},
+		{ID: "4RWNif6C2WCNiKVW7otP4G7dkmkHGyKQWRpuZ1pxKU5m", MinVersion: "1.17.0"},
+	}
+	if !reflect.DeepEqual(cfg.Upgrade.Features, expected) {
+		t.Errorf("Expected features %v, got %v", expected, cfg.Upgrade.Features)
+	}
+}
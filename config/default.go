@@ -0,0 +1,56 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// WriteDefaultConfig writes a commented default config.toml with every config field, its type and description.
+// It's generated from the mapstructure and desc tags of the config structs so that it never drifts from the code.
+// Pointer fields are optional with a default other than their zero value, so they are written commented out.
+func WriteDefaultConfig(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		section := t.Field(i)
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintf(bw, "[%s]\n", section.Tag.Get("mapstructure"))
+
+		for j := 0; j < section.Type.NumField(); j++ {
+			field := section.Type.Field(j)
+			fmt.Fprintf(bw, "# %s (%s)\n", field.Tag.Get("desc"), field.Type)
+			if field.Type.Kind() == reflect.Ptr {
+				fmt.Fprintf(bw, "# %s = %s\n", field.Tag.Get("mapstructure"), defaultValue(field.Type.Elem()))
+				continue
+			}
+			fmt.Fprintf(bw, "%s = %s\n", field.Tag.Get("mapstructure"), defaultValue(field.Type))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// defaultValue returns the toml representation of the zero value of the given type
+func defaultValue(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return `""`
+	case reflect.Bool:
+		return "false"
+	case reflect.Int, reflect.Int64:
+		return "0"
+	case reflect.Float64:
+		return "0.0"
+	case reflect.Slice:
+		return "[]"
+	case reflect.Map:
+		return "{}"
+	default:
+		return `""`
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestWriteDefaultConfig(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDefaultConfig(&buf); err != nil {
+		t.Fatal("Error while writing default config :", err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Error while reading default config: %v\n%s", err, buf.String())
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatal("Error while unmarshaling default config :", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Error("Expected default config to be valid, got ", err)
+	}
+
+	// every field needs a description to be documented in the default config
+	ct := reflect.TypeOf(Config{})
+	for i := 0; i < ct.NumField(); i++ {
+		section := ct.Field(i).Type
+		for j := 0; j < section.NumField(); j++ {
+			if section.Field(j).Tag.Get("desc") == "" {
+				t.Errorf("Expected desc tag on %s.%s", section.Name(), section.Field(j).Name)
+			}
+			// pointer fields are optional and written commented out
+			if section.Field(j).Type.Kind() != reflect.Ptr && !v.IsSet(ct.Field(i).Tag.Get("mapstructure")+"."+section.Field(j).Tag.Get("mapstructure")) {
+				t.Errorf("Expected %s.%s in default config", section.Name(), section.Field(j).Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ReadKeypairPubKey reads a solana-cli keypair file i.e., a JSON array of 64 bytes holding the
+// secret key followed by the public key and returns the public key as base-58 encoded string
+func ReadKeypairPubKey(keypairPath string) (string, error) {
+	data, err := ioutil.ReadFile(keypairPath)
+	if err != nil {
+		return "", err
+	}
+
+	// unmarshal into []int as []byte expects a base64 string
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return "", fmt.Errorf("invalid keypair file %s: %v", keypairPath, err)
+	}
+	if len(values) != 64 {
+		return "", fmt.Errorf("invalid keypair file %s: expected 64 bytes, got %d", keypairPath, len(values))
+	}
+
+	keypair := make([]byte, 0, len(values))
+	for _, v := range values {
+		if v < 0 || v > 255 {
+			return "", fmt.Errorf("invalid keypair file %s: byte value %d out of range", keypairPath, v)
+		}
+		keypair = append(keypair, byte(v))
+	}
+
+	return encodeBase58(keypair[32:]), nil
+}
+
+// resolveKeyPaths derives the validator pub key and vote key from the configured keypair files if any
+func (c *Config) resolveKeyPaths() error {
+	for _, k := range []struct {
+		name, pathName, keyPath string
+		key                     *string
+	}{
+		{name: "pub_key", pathName: "pubkey_path", keyPath: c.ValDetails.PubKeyPath, key: &c.ValDetails.PubKey},
+		{name: "vote_key", pathName: "votekey_path", keyPath: c.ValDetails.VoteKeyPath, key: &c.ValDetails.VoteKey},
+	} {
+		if k.keyPath == "" {
+			continue
+		}
+		if *k.key != "" {
+			return fmt.Errorf("both %s and %s are configured, use only one of them", k.name, k.pathName)
+		}
+		pubKey, err := ReadKeypairPubKey(k.keyPath)
+		if err != nil {
+			return err
+		}
+		*k.key = pubKey
+	}
+	return nil
+}
+
+// encodeBase58 encodes the given bytes using the bitcoin base-58 alphabet used by solana
+func encodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	// every leading zero byte is encoded as the first character of the alphabet
+	for _, v := range b {
+		if v != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeKeypair writes a solana-cli style keypair file with a zero secret key and the given public key
+func writeKeypair(t *testing.T, pubKey string) string {
+	keypairPath := filepath.Join(t.TempDir(), "keypair.json")
+	data := "[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0," + pubKey + "]"
+	if err := ioutil.WriteFile(keypairPath, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return keypairPath
+}
+
+func TestReadKeypairPubKey(t *testing.T) {
+	for _, tc := range []struct {
+		pubKey   string
+		expected string
+	}{
+		{
+			pubKey:   "6,221,246,225,215,101,161,147,217,203,225,70,206,235,121,172,28,180,133,237,95,91,55,145,58,140,245,133,126,255,0,169",
+			expected: "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+		},
+		{
+			pubKey:   "0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0",
+			expected: "11111111111111111111111111111111",
+		},
+	} {
+		pubKey, err := ReadKeypairPubKey(writeKeypair(t, tc.pubKey))
+		if err != nil {
+			t.Fatal("Error while reading keypair :", err)
+		}
+		if pubKey != tc.expected {
+			t.Errorf("Expected pub key %s, got %s", tc.expected, pubKey)
+		}
+	}
+}
+
+func TestResolveKeyPathsConflict(t *testing.T) {
+	cfg := Config{ValDetails: ValDetails{
+		PubKey:     "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+		PubKeyPath: writeKeypair(t, "0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0"),
+	}}
+	err := cfg.resolveKeyPaths()
+	if err == nil || !strings.Contains(err.Error(), "pubkey_path") {
+		t.Errorf("Expected error naming pubkey_path when both pub_key and pubkey_path are configured, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package exporter
+
+import (
+	"log"
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// secondsPerYear is the number of seconds in a year used to annualize epoch rewards
+const secondsPerYear = 365.25 * 24 * 60 * 60
+
+// emitEstimatedAPY exports the estimated APY of and rewards paid to delegators of the validator from the inflation
+// rewards of the previous epoch. The reward is fetched once per epoch, nothing is exported when it can't be estimated.
+func (c *solanaCollector) emitEstimatedAPY(ch chan<- prometheus.Metric, vote types.VoteAccount, epochInfo *types.EpochInfo) {
+	epoch := epochInfo.Result.Epoch
+	if c.apyEpoch != epoch {
+		// the rewards of the previous epoch are paid in the first block of this epoch, a node which pruned it
+		// can't return them and is not asked again until the next epoch
+		firstSlot := epochInfo.Result.AbsoluteSlot - epochInfo.Result.SlotIndex
+		if first, err := monitor.GetFirstAvailableBlock(c.config, utils.Validator); err == nil && firstSlot < first {
+			log.Printf("Skipping inflation reward of epoch %d, first slot %d of epoch %d is before the first available block %d", epoch-1, firstSlot, epoch, first)
+			c.apyEpoch = epoch
+			c.apyOK, c.delegatorRewardOK = false, false
+			return
+		}
+		reward, err := monitor.GetInflationReward(c.config, []string{vote.VotePubkey}, epoch-1)
+		if err != nil {
+			log.Printf("Error while getting inflation reward : %v", err)
+			return
+		}
+		c.apyEpoch = epoch
+		c.apyOK, c.delegatorRewardOK = false, false
+		if len(reward.Result) == 1 && reward.Result[0] != nil {
+			c.apy, c.apyOK = estimatedAPY(reward.Result[0].Amount, vote.ActivatedStake, vote.Commission, epochInfo.Result.SlotsInEpoch)
+			c.delegatorReward, c.delegatorRewardOK = delegatorRewards(reward.Result[0].Amount, vote.Commission)
+		}
+	}
+
+	if c.apyOK {
+		ch <- prometheus.MustNewConstMetric(c.estimatedAPY, prometheus.GaugeValue, c.apy)
+	}
+	if c.delegatorRewardOK {
+		ch <- prometheus.MustNewConstMetric(c.delegatorRewards, prometheus.GaugeValue, c.delegatorReward)
+	}
+}
+
+// delegatorRewards returns the rewards in lamports paid to delegators of a validator in an epoch. The commission
+// reward of the vote account is the commission share of the total epoch reward, delegators get the remaining share.
+// ok is false when there is no commission reward to derive the total reward from.
+func delegatorRewards(commissionReward, commission int64) (reward float64, ok bool) {
+	if commissionReward <= 0 || commission <= 0 || commission >= 100 {
+		return 0, false
+	}
+
+	totalReward := float64(commissionReward) * 100 / float64(commission)
+	return totalReward - float64(commissionReward), true
+}
+
+// estimatedAPY returns the estimated APY in percent earned by delegators of a validator.
+// The delegators reward of the epoch relative to the stake is compounded over the epochs in a year assuming the
+// stake, inflation and performance of the epoch remain the same and slots take 400ms.
+// ok is false when there is no commission reward to derive the total reward from.
+func estimatedAPY(commissionReward, activatedStake, commission, slotsInEpoch int64) (apy float64, ok bool) {
+	delegatorReward, ok := delegatorRewards(commissionReward, commission)
+	if !ok || activatedStake <= 0 || slotsInEpoch <= 0 {
+		return 0, false
+	}
+
+	epochRate := delegatorReward / float64(activatedStake)
+
+	epochsPerYear := secondsPerYear / (float64(slotsInEpoch) * slotDuration.Seconds())
+	return (math.Pow(1+epochRate, epochsPerYear) - 1) * 100, true
+}
@@ -0,0 +1,55 @@
+package exporter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimatedAPY(t *testing.T) {
+	// 1 SOL commission reward at 10% commission on 5000 SOL stake is 9 SOL for delegators per 2 day epoch
+	apy, ok := estimatedAPY(1000000000, 5000000000000, 10, 432000)
+	if !ok {
+		t.Fatal("Expected APY to be estimated")
+	}
+	if math.Abs(apy-38.8785) > 0.001 {
+		t.Errorf("Expected estimated APY 38.8785, got %v", apy)
+	}
+
+	for _, commission := range []int64{0, 100} {
+		if _, ok := estimatedAPY(1000000000, 5000000000000, commission, 432000); ok {
+			t.Errorf("Expected no APY estimate with %d%% commission", commission)
+		}
+	}
+}
+
+func TestDelegatorRewards(t *testing.T) {
+	// 1 SOL commission reward at 10% commission is 9 SOL for delegators
+	reward, ok := delegatorRewards(1000000000, 10)
+	if !ok || reward != 9000000000 {
+		t.Errorf("Expected delegator rewards 9000000000, got %v (ok %v)", reward, ok)
+	}
+
+	for _, commission := range []int64{0, 100} {
+		if _, ok := delegatorRewards(1000000000, commission); ok {
+			t.Errorf("Expected no delegator rewards with %d%% commission", commission)
+		}
+	}
+}
+
+func TestCollectEstimatedAPY(t *testing.T) {
+	results := testRPCResults()
+	results["getInflationReward"] = `[{"epoch": 99, "effectiveSlot": 432000, "amount": 1000000000, "postBalance": 2000000000}]`
+	srv := newTestRPCServer(t, results)
+
+	metrics := collectMetrics(t, NewSolanaCollector(newTestConfig(srv.URL)))
+
+	apy := metrics["solana_validator_estimated_apy"]
+	if len(apy) != 1 || math.Abs(apy[0].GetGauge().GetValue()-38.8785) > 0.001 {
+		t.Errorf("Expected estimated APY 38.8785, got %v", apy)
+	}
+
+	rewards := metrics["solana_delegator_rewards_lamports"]
+	if len(rewards) != 1 || rewards[0].GetGauge().GetValue() != 9000000000 {
+		t.Errorf("Expected delegator rewards 9000000000, got %v", rewards)
+	}
+}
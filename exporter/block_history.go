@@ -0,0 +1,137 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// defaultBlockHistoryEpochs is the number of epochs whose produced blocks are kept if not configured
+const defaultBlockHistoryEpochs = 5
+
+// blockHistory keeps the blocks produced by validator in the most recent epochs
+type blockHistory struct {
+	blocks map[int64]int
+}
+
+// record sets the blocks produced so far in the given epoch and drops the oldest epochs beyond the given number
+// of epochs, which are returned. An epoch older than all the kept ones is ignored once the history is full.
+func (h *blockHistory) record(epoch int64, blocks, epochs int) (dropped []int64) {
+	if h.blocks == nil {
+		h.blocks = make(map[int64]int)
+	}
+	h.blocks[epoch] = blocks
+
+	kept := h.epochs()
+	for len(kept) > epochs {
+		dropped = append(dropped, kept[0])
+		delete(h.blocks, kept[0])
+		kept = kept[1:]
+	}
+	return dropped
+}
+
+// epochs returns the epochs of the history in ascending order
+func (h *blockHistory) epochs() []int64 {
+	epochs := make([]int64, 0, len(h.blocks))
+	for epoch := range h.blocks {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+	return epochs
+}
+
+// blockHistoryEpochs returns the configured number of epochs to keep the produced blocks of, the default if not set
+func blockHistoryEpochs(cfg *config.Config) int {
+	if cfg.Scraper.BlockHistoryEpochs <= 0 {
+		return defaultBlockHistoryEpochs
+	}
+	return cfg.Scraper.BlockHistoryEpochs
+}
+
+// loadBlockHistory reads the produced blocks per epoch from the given json file, an empty history if it doesn't exist
+func loadBlockHistory(path string) (map[int64]int, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[int64]int{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stored map[string]int
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	blocks := make(map[int64]int, len(stored))
+	for epoch, n := range stored {
+		e, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		blocks[e] = n
+	}
+	return blocks, nil
+}
+
+// saveBlockHistory writes the produced blocks per epoch to the given json file. It is written to a temporary
+// file which is then renamed, so a crash never leaves a partial file.
+func saveBlockHistory(path string, blocks map[int64]int) error {
+	stored := make(map[string]int, len(blocks))
+	for epoch, n := range blocks {
+		stored[strconv.FormatInt(epoch, 10)] = n
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// recordBlockHistory records the blocks produced by validator so far in the given epoch, exports the produced
+// blocks of the kept epochs and writes them to the configured history file, from which they are loaded on the
+// first call so that the history survives restarts
+func (c *solanaCollector) recordBlockHistory(cfg *config.Config, epoch int64, blocks int) {
+	path := cfg.Scraper.BlockHistoryFile
+	if c.blockHistory.blocks == nil && path != "" {
+		stored, err := loadBlockHistory(path)
+		if err != nil {
+			log.Printf("Error while reading block history file, starting a new history : %v", err)
+		} else {
+			c.blockHistory.blocks = stored
+		}
+	}
+
+	previous, seen := c.blockHistory.blocks[epoch]
+	for _, e := range c.blockHistory.record(epoch, blocks, blockHistoryEpochs(cfg)) {
+		blocksProducedByEpoch.DeleteLabelValues(strconv.FormatInt(e, 10))
+	}
+	for e, n := range c.blockHistory.blocks {
+		blocksProducedByEpoch.WithLabelValues(strconv.FormatInt(e, 10)).Set(float64(n))
+	}
+
+	if path != "" && (!seen || previous != blocks) {
+		if err := saveBlockHistory(path, c.blockHistory.blocks); err != nil {
+			log.Printf("Error while writing block history file : %v", err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package exporter
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBlockHistoryRetainsLastEpochs(t *testing.T) {
+	var h blockHistory
+	for epoch := int64(100); epoch < 103; epoch++ {
+		if dropped := h.record(epoch, int(epoch-90), 3); len(dropped) != 0 {
+			t.Errorf("Expected no epochs dropped when recording epoch %d, got %v", epoch, dropped)
+		}
+	}
+
+	// updating the current epoch keeps the history as is
+	if dropped := h.record(102, 15, 3); len(dropped) != 0 {
+		t.Errorf("Expected no epochs dropped when updating epoch 102, got %v", dropped)
+	}
+
+	if dropped := h.record(103, 4, 3); !reflect.DeepEqual(dropped, []int64{100}) {
+		t.Errorf("Expected epoch 100 dropped when recording epoch 103, got %v", dropped)
+	}
+	want := map[int64]int{101: 11, 102: 15, 103: 4}
+	if !reflect.DeepEqual(h.blocks, want) {
+		t.Errorf("Expected history %v, got %v", want, h.blocks)
+	}
+
+	// shrinking the number of epochs drops all the older ones at once
+	if dropped := h.record(104, 1, 2); !reflect.DeepEqual(dropped, []int64{101, 102}) {
+		t.Errorf("Expected epochs 101 and 102 dropped when recording epoch 104, got %v", dropped)
+	}
+	if got := h.epochs(); !reflect.DeepEqual(got, []int64{103, 104}) {
+		t.Errorf("Expected epochs [103 104], got %v", got)
+	}
+}
+
+func TestBlockHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "block_history.json")
+
+	blocks, err := loadBlockHistory(path)
+	if err != nil || len(blocks) != 0 {
+		t.Fatalf("Expected an empty history from a missing file, got %v, %v", blocks, err)
+	}
+
+	want := map[int64]int{101: 11, 102: 15}
+	if err := saveBlockHistory(path, want); err != nil {
+		t.Fatal("Error while saving block history :", err)
+	}
+	got, err := loadBlockHistory(path)
+	if err != nil {
+		t.Fatal("Error while loading block history :", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected loaded history %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,69 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+const (
+	// leaderWindowSlots is the number of consecutive slots a leader is scheduled for
+	leaderWindowSlots = 4
+	// blockOverdueFactor is how many expected leader intervals may pass without a produced block before alerting
+	blockOverdueFactor = 3
+)
+
+// blockTracker remembers when the validator last produced a block from the blocks produced in the epoch
+type blockTracker struct {
+	epoch       int64
+	blocks      int
+	lastBlockAt time.Time
+}
+
+// add records the blocks produced by the validator so far in the given epoch and returns the time since it
+// last produced a block. Until a block is seen it's the time since monitoring started.
+func (t *blockTracker) add(epoch int64, blocks int, now time.Time) time.Duration {
+	switch {
+	case t.lastBlockAt.IsZero():
+		t.lastBlockAt = now
+	case epoch == t.epoch && blocks > t.blocks, epoch != t.epoch && blocks > 0:
+		t.lastBlockAt = now
+	}
+	t.epoch, t.blocks = epoch, blocks
+	return now.Sub(t.lastBlockAt)
+}
+
+// expectedBlockInterval returns the expected time between leader windows of the validator from the leader slots
+// it had in the elapsed slots of the epoch. ok is false when it had no full leader window yet.
+func expectedBlockInterval(leaderSlots int, slotIndex int64) (interval time.Duration, ok bool) {
+	windows := leaderSlots / leaderWindowSlots
+	if windows == 0 || slotIndex <= 0 {
+		return 0, false
+	}
+	return time.Duration(slotIndex) * slotDuration / time.Duration(windows), true
+}
+
+// trackBlockProduction exports the time since the validator last produced a block and sends an alert when
+// it exceeds blockOverdueFactor times the expected interval of its leader windows
+func (c *solanaCollector) trackBlockProduction(cfg *config.Config, bp monitor.RecentBlock, epoch, slotIndex int64) {
+	since := c.lastBlock.add(epoch, bp.BlocksProduced, time.Now())
+	secondsSinceLastBlock.Set(since.Seconds())
+
+	if !strings.EqualFold(cfg.AlerterPreferences.BlockProductionAlerts, "yes") {
+		return
+	}
+	interval, ok := expectedBlockInterval(bp.LeaderSlots, slotIndex)
+	overdue := ok && since > blockOverdueFactor*interval
+	if overdue && !c.blockOverdueAlerted {
+		err := alerter.SendAlert(alerter.EventBlockProduction, fmt.Sprintf("Block Production Alert : Your validator has not produced a block for %s, its leader windows are expected every %s", since.Round(time.Second), interval.Round(time.Second)), alerter.Warning, cfg)
+		if err != nil {
+			log.Printf("Error while sending block production alert: %v", err)
+		}
+	}
+	c.blockOverdueAlerted = overdue
+}
@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockTracker(t *testing.T) {
+	var tracker blockTracker
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		after  time.Duration
+		epoch  int64
+		blocks int
+		since  time.Duration
+	}{
+		{0, 100, 8, 0},                              // monitoring starts
+		{time.Minute, 100, 8, time.Minute},          // no new block
+		{2 * time.Minute, 100, 12, 0},               // produced blocks
+		{5 * time.Minute, 100, 12, 3 * time.Minute}, // no new block
+		{6 * time.Minute, 101, 0, 4 * time.Minute},  // new epoch without blocks yet
+		{10 * time.Minute, 101, 4, 0},               // first blocks of new epoch
+		{15 * time.Minute, 101, 4, 5 * time.Minute}, // no new block
+	} {
+		if since := tracker.add(tc.epoch, tc.blocks, start.Add(tc.after)); since != tc.since {
+			t.Errorf("Expected %s since last block after %s, got %s", tc.since, tc.after, since)
+		}
+	}
+}
+
+func TestExpectedBlockInterval(t *testing.T) {
+	// 4 leader windows in the first 36000 slots i.e., 4 hours of the epoch
+	interval, ok := expectedBlockInterval(16, 36000)
+	if !ok || interval != time.Hour {
+		t.Errorf("Expected block interval of 1h, got %s (ok %v)", interval, ok)
+	}
+
+	if _, ok := expectedBlockInterval(2, 36000); ok {
+		t.Error("Expected no block interval without a full leader window")
+	}
+}
@@ -0,0 +1,92 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// catchupTracker follows the node catching up with the cluster, e.g. replaying after a restart, from the first
+// health check reporting it behind until it reports it healthy again
+type catchupTracker struct {
+	trend lagTrend
+	start time.Time
+	peak  int64
+}
+
+// catchup is the progress of the node catching up with the cluster
+type catchup struct {
+	// remaining is the number of slots the node is still behind
+	remaining int64
+	// eta is the estimated seconds until the node caught up, -1 while the slots behind aren't decreasing
+	eta float64
+	// caughtUp is set once when the node is healthy again, with the most slots it was behind and for how long
+	caughtUp bool
+	peak     int64
+	took     time.Duration
+}
+
+// track records the slots the node is behind the cluster at the given time, 0 once it is healthy
+func (t *catchupTracker) track(now time.Time, behind int64) catchup {
+	if behind <= 0 {
+		if t.start.IsZero() {
+			return catchup{}
+		}
+		c := catchup{caughtUp: true, peak: t.peak, took: now.Sub(t.start)}
+		*t = catchupTracker{}
+		return c
+	}
+
+	if t.start.IsZero() {
+		t.start = now
+	}
+	if behind > t.peak {
+		t.peak = behind
+	}
+	t.trend.add(now, behind)
+
+	c := catchup{remaining: behind, eta: -1}
+	if slope, ok := t.trend.slope(); ok && slope < 0 {
+		c.eta = float64(behind) / -slope
+	}
+	return c
+}
+
+// watchCatchup exports the progress of the node catching up with the cluster from its health check and sends a
+// recovery alert once it caught up from being at least the configured slots behind threshold behind and validator
+// is voting again per the last scrape, rpc nodes don't vote so they only need to be healthy. The health check is
+// skipped while the node is not reachable, as it can't tell how far behind it is then.
+func (c *solanaCollector) watchCatchup(cfg *config.Config, health float64, behind int64, err error) {
+	if err != nil || (health == 0 && behind == 0) {
+		return
+	}
+
+	progress := c.catchup.track(time.Now(), behind)
+	catchupSlotsRemaining.Set(float64(progress.remaining))
+	catchupETA.Set(progress.eta)
+
+	threshold := cfg.AlertingThresholds.SlotsBehindThreshold
+	switch {
+	case progress.remaining > 0:
+		// fell behind again before it voted
+		c.catchupPending = nil
+	case progress.caughtUp && threshold > 0 && progress.peak >= threshold:
+		c.catchupPending = &progress
+	}
+	if c.catchupPending == nil {
+		return
+	}
+	if status, _ := c.lastVotingStatus.Load().(string); status != "voting" && !cfg.IsRPCNode() {
+		return
+	}
+
+	err = alerter.SendAlert(alerter.EventCatchup, fmt.Sprintf("Catch Up Alert : Your node caught up with the cluster and is voting again, it was up to %d slots behind for %s",
+		c.catchupPending.peak, c.catchupPending.took.Round(time.Second)), alerter.Info, cfg)
+	if err != nil {
+		log.Printf("Error while sending catch up alert: %v", err)
+	}
+	c.catchupPending = nil
+}
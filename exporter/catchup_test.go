@@ -0,0 +1,74 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestCatchupTracker(t *testing.T) {
+	var tracker catchupTracker
+	start := time.Now()
+
+	// the node replays 100 slots per second faster than the cluster
+	for i, tc := range []struct {
+		behind int64
+		eta    float64
+	}{
+		{1000, -1},
+		{800, -1},
+		{600, 6},
+		{400, 4},
+	} {
+		c := tracker.track(start.Add(time.Duration(i)*2*time.Second), tc.behind)
+		if c.remaining != tc.behind || c.eta != tc.eta || c.caughtUp {
+			t.Errorf("Expected %d slots remaining with an eta of %vs at sample %d, got %+v", tc.behind, tc.eta, i, c)
+		}
+	}
+
+	c := tracker.track(start.Add(8*time.Second), 0)
+	if !c.caughtUp || c.peak != 1000 || c.took != 8*time.Second || c.remaining != 0 || c.eta != 0 {
+		t.Errorf("Expected to have caught up from 1000 slots behind in 8s, got %+v", c)
+	}
+	if c := tracker.track(start.Add(10*time.Second), 0); c.caughtUp {
+		t.Errorf("Expected caught up to be reported once, got %+v", c)
+	}
+}
+
+func TestWatchCatchup(t *testing.T) {
+	cfg := newTestConfig("")
+	cfg.AlertingThresholds.SlotsBehindThreshold = 150
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, alerter.EventCatchup, alerter.Info)
+	for _, behind := range []int64{500, 300, 100} {
+		c.watchCatchup(cfg, 0, behind, nil)
+	}
+	if got := testutil.ToFloat64(catchupSlotsRemaining); got != 100 {
+		t.Errorf("Expected 100 catch up slots remaining, got %v", got)
+	}
+	if got := alertsSent(t, alerter.EventCatchup, alerter.Info) - before; got != 0 {
+		t.Errorf("Expected no catch up alert while catching up, got %v", got)
+	}
+
+	// healthy but not voting yet
+	c.lastVotingStatus.Store("delinquent")
+	c.watchCatchup(cfg, 1, 0, nil)
+	if got := testutil.ToFloat64(catchupSlotsRemaining); got != 0 {
+		t.Errorf("Expected no catch up slots remaining once healthy, got %v", got)
+	}
+	if got := alertsSent(t, alerter.EventCatchup, alerter.Info) - before; got != 0 {
+		t.Errorf("Expected no catch up alert before validator votes, got %v", got)
+	}
+
+	c.lastVotingStatus.Store("voting")
+	for i := 0; i < 2; i++ {
+		c.watchCatchup(cfg, 1, 0, nil)
+	}
+	if got := alertsSent(t, alerter.EventCatchup, alerter.Info) - before; got != 1 {
+		t.Errorf("Expected 1 catch up alert once healthy and voting, got %v", got)
+	}
+}
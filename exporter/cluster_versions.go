@@ -0,0 +1,81 @@
+package exporter
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+const (
+	// clusterVersionsTopN is the number of most common versions exported, the rest are counted as other
+	clusterVersionsTopN = 10
+	// clusterNodesCacheDuration is how long cluster nodes are cached, as the list is large and changes slowly
+	clusterNodesCacheDuration = 5 * time.Minute
+)
+
+// emitClusterVersions exports the number of cluster nodes running each software version
+func (c *solanaCollector) emitClusterVersions(ch chan<- prometheus.Metric) {
+	nodes, err := c.getCachedClusterNodes()
+	if err != nil {
+		log.Printf("Error while getting cluster nodes : %v", err)
+		return
+	}
+
+	for version, count := range clusterVersions(nodes, clusterVersionsTopN) {
+		ch <- prometheus.MustNewConstMetric(c.clusterVersions, prometheus.GaugeValue, float64(count), version)
+	}
+}
+
+// getCachedClusterNodes returns cached cluster nodes or fetches them again if cache is expired
+func (c *solanaCollector) getCachedClusterNodes() (*types.ClustrNode, error) {
+	if c.cachedClusterNodes != nil && time.Since(c.cachedClusterNodesTime) < clusterNodesCacheDuration {
+		return c.cachedClusterNodes, nil
+	}
+
+	nodes, err := monitor.GetClusterNodes(c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cachedClusterNodes = &nodes
+	c.cachedClusterNodesTime = time.Now()
+	return &nodes, nil
+}
+
+// clusterVersions counts the cluster nodes per version. Only the topN most common versions are kept,
+// the nodes of the remaining versions are counted as "other" to bound the cardinality.
+func clusterVersions(nodes *types.ClustrNode, topN int) map[string]int {
+	counts := make(map[string]int)
+	for _, node := range nodes.Result {
+		version := node.Version
+		if version == "" {
+			version = "unknown"
+		}
+		counts[version]++
+	}
+
+	versions := make([]string, 0, len(counts))
+	for version := range counts {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if counts[versions[i]] != counts[versions[j]] {
+			return counts[versions[i]] > counts[versions[j]]
+		}
+		return versions[i] < versions[j]
+	})
+
+	if len(versions) <= topN {
+		return counts
+	}
+	for _, version := range versions[topN:] {
+		counts["other"] += counts[version]
+		delete(counts, version)
+	}
+	return counts
+}
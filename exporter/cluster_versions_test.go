@@ -0,0 +1,36 @@
+package exporter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestClusterVersions(t *testing.T) {
+	var nodes types.ClustrNode
+	err := json.Unmarshal([]byte(`{"result": [
+		{"pubkey": "a", "version": "1.14.17"},
+		{"pubkey": "b", "version": "1.14.17"},
+		{"pubkey": "c", "version": "1.14.17"},
+		{"pubkey": "d", "version": "1.13.6"},
+		{"pubkey": "e", "version": "1.13.6"},
+		{"pubkey": "f", "version": "1.15.0"},
+		{"pubkey": "g", "version": "1.10.1"},
+		{"pubkey": "h", "version": null}
+	]}`), &nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]int{"1.14.17": 3, "1.13.6": 2, "other": 3}
+	if got := clusterVersions(&nodes, 2); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected cluster versions %v, got %v", expected, got)
+	}
+
+	expected = map[string]int{"1.14.17": 3, "1.13.6": 2, "1.15.0": 1, "1.10.1": 1, "unknown": 1}
+	if got := clusterVersions(&nodes, 10); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected cluster versions %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,32 @@
+package exporter
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectors added by external code with RegisterCollector, served on /metrics next to the built-in metrics
+var (
+	collectorsMu sync.Mutex
+	collectors   []prometheus.Collector
+)
+
+// RegisterCollector adds a custom collector e.g. of the metrics of a sidecar, whose metrics are then served on
+// /metrics and written to the metrics file like the built-in ones, with the static labels attached. It must be
+// called before the metrics are registered at startup, e.g. from the init function of a file added to package main.
+// See docs/custom-collectors.md.
+func RegisterCollector(c prometheus.Collector) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	collectors = append(collectors, c)
+}
+
+// RegisterCollectors registers the custom collectors added with RegisterCollector with the given registerer
+func RegisterCollectors(r prometheus.Registerer) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	for _, c := range collectors {
+		r.MustRegister(c)
+	}
+}
@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestRegisterCollector(t *testing.T) {
+	defer func() { collectors = nil }()
+
+	sidecar := prometheus.NewGauge(prometheus.GaugeOpts{Name: "sidecar_disk_free_bytes", Help: "Free disk space reported by a sidecar"})
+	sidecar.Set(1024)
+	RegisterCollector(sidecar)
+
+	reg := prometheus.NewRegistry()
+	RegisterCollectors(prometheus.WrapRegistererWith(prometheus.Labels{"region": "eu"}, reg))
+
+	srv := NewMetricsServer(&config.Config{}, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}), reg)
+	ts := httptest.NewServer(srv.Handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal("Error while requesting metrics :", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `sidecar_disk_free_bytes{region="eu"} 1024`) {
+		t.Errorf("Expected the custom collector metric on /metrics, got %s", body)
+	}
+}
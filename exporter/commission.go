@@ -0,0 +1,47 @@
+package exporter
+
+import (
+	"sort"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// averageCommission returns the unweighted and activated stake weighted average commission in percent of the
+// given vote accounts. ok is false when there are no vote accounts to average.
+func averageCommission(accounts []types.VoteAccount) (unweighted, weighted float64, ok bool) {
+	if len(accounts) == 0 {
+		return 0, 0, false
+	}
+
+	var sum, weightedSum, stake float64
+	for _, account := range accounts {
+		sum += float64(account.Commission)
+		weightedSum += float64(account.Commission) * float64(account.ActivatedStake)
+		stake += float64(account.ActivatedStake)
+	}
+
+	unweighted = sum / float64(len(accounts))
+	if stake > 0 {
+		weighted = weightedSum / stake
+	}
+	return unweighted, weighted, true
+}
+
+// medianCommission returns the median commission in percent of the given commissions, the mean of the two middle
+// ones for an even count. It is more robust to outliers like 100% commission accounts than the average. ok is
+// false when there are no commissions.
+func medianCommission(commissions []int64) (median float64, ok bool) {
+	if len(commissions) == 0 {
+		return 0, false
+	}
+
+	sorted := make([]int64, len(commissions))
+	copy(sorted, commissions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return float64(sorted[mid-1]+sorted[mid]) / 2, true
+	}
+	return float64(sorted[mid]), true
+}
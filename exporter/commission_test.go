@@ -0,0 +1,54 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestAverageCommission(t *testing.T) {
+	accounts := []types.VoteAccount{
+		{Commission: 10, ActivatedStake: 1000},
+		{Commission: 0, ActivatedStake: 3000},
+		{Commission: 100, ActivatedStake: 0},
+	}
+
+	unweighted, weighted, ok := averageCommission(accounts)
+	if !ok {
+		t.Fatal("Expected average commission")
+	}
+	if unweighted != 110.0/3 {
+		t.Errorf("Expected unweighted average commission %v, got %v", 110.0/3, unweighted)
+	}
+	if weighted != 2.5 {
+		t.Errorf("Expected stake weighted average commission 2.5, got %v", weighted)
+	}
+
+	if _, _, ok := averageCommission(nil); ok {
+		t.Error("Expected no average commission without vote accounts")
+	}
+}
+
+func TestMedianCommission(t *testing.T) {
+	for _, tc := range []struct {
+		commissions []int64
+		expected    float64
+	}{
+		// the 100% outliers don't pull the median up like the average
+		{[]int64{100, 5, 7, 100, 8, 0, 10}, 8},
+		{[]int64{10, 0, 5, 7}, 6},
+		{[]int64{10}, 10},
+	} {
+		median, ok := medianCommission(tc.commissions)
+		if !ok {
+			t.Fatalf("Expected median commission of %v", tc.commissions)
+		}
+		if median != tc.expected {
+			t.Errorf("Expected median commission %v of %v, got %v", tc.expected, tc.commissions, median)
+		}
+	}
+
+	if _, ok := medianCommission(nil); ok {
+		t.Error("Expected no median commission without vote accounts")
+	}
+}
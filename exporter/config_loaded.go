@@ -0,0 +1,18 @@
+package exporter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var configLoadedTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "solana_config_loaded_timestamp",
+	Help: "Unix time in seconds at which the config was last loaded successfully",
+})
+
+// ConfigLoaded records the given time as the time the config was last loaded successfully. It is called after
+// the config is read at startup and should be called after every successful reload.
+func ConfigLoaded(at time.Time) {
+	configLoadedTimestamp.Set(float64(at.Unix()))
+}
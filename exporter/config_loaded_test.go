@@ -0,0 +1,30 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestConfigLoaded(t *testing.T) {
+	loaded := func() float64 {
+		var m dto.Metric
+		if err := configLoadedTimestamp.Write(&m); err != nil {
+			t.Fatal("Error while reading config loaded timestamp :", err)
+		}
+		return m.GetGauge().GetValue()
+	}
+
+	start := time.Now()
+	ConfigLoaded(start)
+	if got := loaded(); got != float64(start.Unix()) {
+		t.Errorf("Expected config loaded at %d, got %v", start.Unix(), got)
+	}
+
+	// a reload advances the timestamp
+	ConfigLoaded(start.Add(time.Minute))
+	if got := loaded(); got != float64(start.Unix()+60) {
+		t.Errorf("Expected config reloaded at %d, got %v", start.Unix()+60, got)
+	}
+}
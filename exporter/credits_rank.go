@@ -0,0 +1,81 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// rankTracker tracks the best vote credits rank of validator within an epoch
+type rankTracker struct {
+	epoch int64
+	best  int
+}
+
+// track records the rank of validator in the given epoch and returns the number of places it has worsened
+// since the best rank of the epoch, 0 if it is at its best. A new epoch starts from its first rank.
+func (rt *rankTracker) track(epoch int64, rank int) int {
+	if rt.best == 0 || epoch != rt.epoch || rank < rt.best {
+		rt.epoch = epoch
+		rt.best = rank
+	}
+	return rank - rt.best
+}
+
+// creditsRank returns the rank of the vote account among the given vote accounts by the credits earned
+// in the epoch, 1 for the most credits. Vote accounts with equal credits share a rank. ok is false if the
+// vote account is not among them.
+func creditsRank(accounts []types.VoteAccount, votePubkey string, epoch int64) (rank int, ok bool) {
+	earned := make(map[string]int64, len(accounts))
+	for _, account := range accounts {
+		for _, c := range account.EpochCredits {
+			if len(c) >= 3 && c[0] == epoch {
+				earned[account.VotePubkey] = c[1] - c[2]
+			}
+		}
+	}
+
+	own, ok := earned[votePubkey]
+	if !ok {
+		return 0, false
+	}
+	rank = 1
+	for _, credits := range earned {
+		if credits > own {
+			rank++
+		}
+	}
+	return rank, true
+}
+
+// emitCreditsRank exports the vote credits rank of validator among the current vote accounts and the places
+// it has worsened since its best rank of the epoch, and sends an alert when it worsens by more than the
+// configured number of places
+func (c *solanaCollector) emitCreditsRank(ch chan<- prometheus.Metric, accounts []types.VoteAccount, votePubkey string, epoch int64) {
+	rank, ok := creditsRank(accounts, votePubkey, epoch)
+	if !ok {
+		return
+	}
+	drop := c.creditsRank.track(epoch, rank)
+
+	ch <- prometheus.MustNewConstMetric(c.voteCreditsRank, prometheus.GaugeValue, float64(rank))
+	ch <- prometheus.MustNewConstMetric(c.voteCreditsRankDelta, prometheus.GaugeValue, float64(drop))
+
+	threshold := c.config.AlertingThresholds.VoteCreditsRankDropThreshold
+	if threshold <= 0 {
+		return
+	}
+	dropped := int64(drop) > threshold
+	if dropped && !c.creditsRankAlerted {
+		err := alerter.SendAlert(alerter.EventCreditsRank, fmt.Sprintf("Vote Credits Rank Alert : Your validator dropped %d places to rank %d by vote credits in epoch %d, more than the configured %d",
+			drop, rank, epoch, threshold), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending vote credits rank alert: %v", err)
+		}
+	}
+	c.creditsRankAlerted = dropped
+}
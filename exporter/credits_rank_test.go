@@ -0,0 +1,106 @@
+package exporter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestCreditsRank(t *testing.T) {
+	accounts := []types.VoteAccount{
+		{VotePubkey: "a", EpochCredits: [][]int64{{99, 1000, 0}, {100, 1500, 1000}}},
+		{VotePubkey: "b", EpochCredits: [][]int64{{100, 2300, 2000}}},
+		{VotePubkey: "c", EpochCredits: [][]int64{{100, 3500, 3000}}},
+		{VotePubkey: "d", EpochCredits: [][]int64{{99, 9000, 0}}},
+	}
+
+	for _, tc := range []struct {
+		vote string
+		rank int
+		ok   bool
+	}{
+		{"a", 1, true},
+		{"c", 1, true},
+		{"b", 3, true},
+		{"d", 0, false},
+	} {
+		rank, ok := creditsRank(accounts, tc.vote, 100)
+		if rank != tc.rank || ok != tc.ok {
+			t.Errorf("Expected rank of %s to be %d, %v, got %d, %v", tc.vote, tc.rank, tc.ok, rank, ok)
+		}
+	}
+}
+
+func TestRankTracker(t *testing.T) {
+	var rt rankTracker
+	for _, step := range []struct {
+		epoch int64
+		rank  int
+		drop  int
+	}{
+		{100, 20, 0},
+		{100, 10, 0},
+		{100, 25, 15},
+		{100, 12, 2},
+		{101, 40, 0},
+		{101, 45, 5},
+	} {
+		if got := rt.track(step.epoch, step.rank); got != step.drop {
+			t.Errorf("Expected drop %d at rank %d in epoch %d, got %d", step.drop, step.rank, step.epoch, got)
+		}
+	}
+}
+
+// rankedVoteAccounts returns vote accounts where validator earned the given credits this epoch and three
+// others earned 1000 credits each
+func rankedVoteAccounts(credits int) string {
+	return fmt.Sprintf(`{
+	"current": [
+		{"activatedStake": 5000000000000, "commission": 10, "epochCredits": [[100, %d, 3000]], "epochVoteAccount": true, "lastVote": 1000, "nodePubkey": "valPubKey", "rootSlot": 968, "votePubkey": "valVoteKey"},
+		{"activatedStake": 1000000000000, "commission": 5, "epochCredits": [[100, 5000, 4000]], "epochVoteAccount": true, "lastVote": 1002, "nodePubkey": "otherPubKey", "rootSlot": 970, "votePubkey": "otherVoteKey"},
+		{"activatedStake": 1000000000000, "commission": 5, "epochCredits": [[100, 6000, 5000]], "epochVoteAccount": true, "lastVote": 1002, "nodePubkey": "secondPubKey", "rootSlot": 970, "votePubkey": "secondVoteKey"},
+		{"activatedStake": 1000000000000, "commission": 5, "epochCredits": [[100, 7000, 6000]], "epochVoteAccount": true, "lastVote": 1002, "nodePubkey": "thirdPubKey", "rootSlot": 970, "votePubkey": "thirdVoteKey"}
+	],
+	"delinquent": []
+}`, 3000+credits)
+}
+
+func TestCollectCreditsRankDrop(t *testing.T) {
+	results := testRPCResults()
+	results["getVoteAccounts"] = rankedVoteAccounts(1200)
+	srv := newTestRPCServer(t, results)
+	cfg := newTestConfig(srv.URL)
+	cfg.AlertingThresholds.VoteCreditsRankDropThreshold = 1
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, alerter.EventCreditsRank, alerter.Warning)
+
+	rank := func() (float64, float64) {
+		metrics := collectMetrics(t, c)
+		r, d := metrics["solana_validator_vote_credits_rank"], metrics["solana_validator_vote_credits_rank_delta"]
+		if len(r) != 1 || len(d) != 1 {
+			t.Fatalf("Expected vote credits rank metrics, got %v and %v", r, d)
+		}
+		return r[0].GetGauge().GetValue(), d[0].GetGauge().GetValue()
+	}
+
+	if r, d := rank(); r != 1 || d != 0 {
+		t.Errorf("Expected rank 1 and delta 0, got %v and %v", r, d)
+	}
+
+	// others overtake validator by 100 credits, a drop of 3 places crosses the threshold
+	results["getVoteAccounts"] = rankedVoteAccounts(900)
+	if r, d := rank(); r != 4 || d != 3 {
+		t.Errorf("Expected rank 4 and delta 3, got %v and %v", r, d)
+	}
+	// no repeated alert while the rank stays dropped
+	if r, d := rank(); r != 4 || d != 3 {
+		t.Errorf("Expected rank 4 and delta 3, got %v and %v", r, d)
+	}
+
+	if got := alertsSent(t, alerter.EventCreditsRank, alerter.Warning) - before; got != 1 {
+		t.Errorf("Expected 1 vote credits rank alert, got %v", got)
+	}
+}
@@ -0,0 +1,104 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+const (
+	// delinquentSlotDistance is how many slots the last vote of a vote account may be behind before the cluster
+	// considers it delinquent
+	delinquentSlotDistance = 128
+	// maxLagSamples is the number of recent vote lag samples the trend is fitted over
+	maxLagSamples = 10
+	// minLagSamples is the number of vote lag samples needed before a trend is fitted
+	minLagSamples = 3
+)
+
+// lagSample is the vote lag of validator at a time
+type lagSample struct {
+	at  time.Time
+	lag int64
+}
+
+// lagTrend keeps the recent vote lag samples of validator
+type lagTrend struct {
+	samples []lagSample
+}
+
+// add records the vote lag at the given time, only the most recent maxLagSamples are kept
+func (t *lagTrend) add(at time.Time, lag int64) {
+	t.samples = append(t.samples, lagSample{at: at, lag: lag})
+	if len(t.samples) > maxLagSamples {
+		t.samples = t.samples[len(t.samples)-maxLagSamples:]
+	}
+}
+
+// secondsToDelinquency extrapolates the linear fit of the vote lag samples to the time the lag reaches
+// delinquentSlotDistance, starting from the latest lag. ok is false while there are too few samples or the lag
+// isn't increasing.
+func (t *lagTrend) secondsToDelinquency() (seconds float64, ok bool) {
+	slope, ok := t.slope()
+	if !ok || slope <= 0 {
+		return 0, false
+	}
+
+	remaining := float64(delinquentSlotDistance - t.samples[len(t.samples)-1].lag)
+	if remaining <= 0 {
+		return 0, true
+	}
+	return remaining / slope, true
+}
+
+// slope returns the least squares slope of the lag samples in slots per second, ok is false while there are too
+// few samples or they were all taken at the same time
+func (t *lagTrend) slope() (slope float64, ok bool) {
+	n := len(t.samples)
+	if n < minLagSamples {
+		return 0, false
+	}
+
+	first := t.samples[0].at
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range t.samples {
+		x, y := s.at.Sub(first).Seconds(), float64(s.lag)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denom := float64(n)*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, false
+	}
+	return (float64(n)*sumXY - sumX*sumY) / denom, true
+}
+
+// emitDelinquencyForecast records the vote lag of validator, exports the estimated seconds until it becomes
+// delinquent while the lag is increasing and sends an alert when that is within the configured horizon
+func (c *solanaCollector) emitDelinquencyForecast(ch chan<- prometheus.Metric, lag int64, now time.Time) {
+	c.voteLagTrend.add(now, lag)
+	seconds, ok := c.voteLagTrend.secondsToDelinquency()
+	if ok {
+		ch <- prometheus.MustNewConstMetric(c.secondsToDelinquency, prometheus.GaugeValue, seconds)
+	}
+
+	horizon := c.config.AlertingThresholds.DelinquencyHorizonSeconds
+	if horizon <= 0 {
+		return
+	}
+	soon := ok && seconds < float64(horizon)
+	if soon && !c.delinquencyForecastAlerted {
+		err := alerter.SendAlert(alerter.EventDelinquencyForecast, fmt.Sprintf("Delinquency Forecast Alert : The vote lag of your validator is increasing, at this rate it becomes delinquent in %s",
+			(time.Duration(seconds)*time.Second).String()), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending delinquency forecast alert: %v", err)
+		}
+	}
+	c.delinquencyForecastAlerted = soon
+}
@@ -0,0 +1,39 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLagTrendSecondsToDelinquency(t *testing.T) {
+	var trend lagTrend
+	start := time.Unix(1700000000, 0)
+
+	// a steady lag is not heading to delinquency
+	for i := 0; i < 3; i++ {
+		trend.add(start.Add(time.Duration(i)*30*time.Second), 4)
+	}
+	if _, ok := trend.secondsToDelinquency(); ok {
+		t.Error("Expected no estimate for a steady lag")
+	}
+
+	// the lag grows by 10 slots every 30 seconds
+	trend = lagTrend{}
+	for i := 0; i < 5; i++ {
+		trend.add(start.Add(time.Duration(i)*30*time.Second), int64(8+10*i))
+		if _, ok := trend.secondsToDelinquency(); ok != (i >= minLagSamples-1) {
+			t.Errorf("Expected estimate %v after %d samples, got %v", i >= minLagSamples-1, i+1, ok)
+		}
+	}
+	seconds, ok := trend.secondsToDelinquency()
+	// 80 slots left from the latest lag of 48 at 1/3 slot per second
+	if !ok || seconds < 239.9 || seconds > 240.1 {
+		t.Errorf("Expected 240 seconds to delinquency, got %v, %v", seconds, ok)
+	}
+
+	// once the lag is past the delinquency distance it is due now
+	trend.add(start.Add(5*30*time.Second), 130)
+	if seconds, ok := trend.secondsToDelinquency(); !ok || seconds != 0 {
+		t.Errorf("Expected 0 seconds to delinquency, got %v, %v", seconds, ok)
+	}
+}
@@ -0,0 +1,59 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// jsonSample is a single sample of a metric in the json dump
+type jsonSample struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  float64           `json:"value"`
+}
+
+// DumpJSON gathers the metrics once and writes them to w as a json object of metric name to its samples.
+// Metrics which couldn't be collected are left out, the error of gathering them is returned after writing the others.
+func DumpJSON(w io.Writer, g prometheus.Gatherer) error {
+	mfs, gatherErr := g.Gather()
+
+	dump := make(map[string][]jsonSample)
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			value, ok := sampleValue(mf.GetType(), m)
+			if !ok {
+				continue
+			}
+			sample := jsonSample{Value: value}
+			if len(m.GetLabel()) > 0 {
+				sample.Labels = make(map[string]string)
+				for _, l := range m.GetLabel() {
+					sample.Labels[l.GetName()] = l.GetValue()
+				}
+			}
+			dump[mf.GetName()] = append(dump[mf.GetName()], sample)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(dump); err != nil {
+		return err
+	}
+	return gatherErr
+}
+
+// sampleValue returns the value of a gauge, counter or untyped metric, histograms and summaries have no single value
+func sampleValue(t dto.MetricType, m *dto.Metric) (float64, bool) {
+	switch t {
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), true
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), true
+	case dto.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,50 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+func TestDumpJSON(t *testing.T) {
+	cfg := newTestConfig("http://rpc.invalid")
+	cfg.ValDetails.PubKey = "FixtureVa1idator1dentity11111111111111111111"
+	cfg.ValDetails.VoteKey = "FixtureVa1idatorVote111111111111111111111111"
+	cfg.TestMode = config.TestMode{Enabled: true, FixturesDir: "../fixtures/rpc"}
+
+	if err := monitor.ConfigureTestMode(cfg); err != nil {
+		t.Fatal("Error while configuring test mode :", err)
+	}
+	defer monitor.ConfigureProxy(&config.Config{})
+
+	r := prometheus.NewRegistry()
+	r.MustRegister(NewSolanaCollector(cfg))
+
+	var buf bytes.Buffer
+	if err := DumpJSON(&buf, r); err != nil {
+		t.Fatal("Error while dumping metrics :", err)
+	}
+
+	var dump map[string][]jsonSample
+	if err := json.Unmarshal(buf.Bytes(), &dump); err != nil {
+		t.Fatalf("Expected a json object, got %s: %v", buf.String(), err)
+	}
+
+	if s := dump["solana_tx_count"]; len(s) != 1 || s[0].Value != 123456 {
+		t.Errorf("Expected solana_tx_count 123456, got %v", s)
+	}
+	stake := dump["solana_validator_activated_stake_sol"]
+	if len(stake) != 1 || stake[0].Value != 5000 || stake[0].Labels["votekey"] != cfg.ValDetails.VoteKey {
+		t.Errorf("Expected activated stake 5000 with votekey label, got %v", stake)
+	}
+	for _, name := range []string{"solana_node_version", "solana_current_slot", "solana_validator_active"} {
+		if _, ok := dump[name]; !ok {
+			t.Errorf("Expected %s in json dump", name)
+		}
+	}
+}
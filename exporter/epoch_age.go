@@ -0,0 +1,21 @@
+package exporter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// epochAge estimates the time since the current epoch began from the slot index of the epoch info and the
+// time passed since it was fetched
+func epochAge(slotIndex int64, fetchedAgo time.Duration) time.Duration {
+	return time.Duration(slotIndex)*slotDuration + fetchedAgo
+}
+
+// emitEpochAge exports the estimated time since the current epoch began from the cached epoch info
+func (c *solanaCollector) emitEpochAge(ch chan<- prometheus.Metric, epochInfo *types.EpochInfo) {
+	age := epochAge(epochInfo.Result.SlotIndex, time.Since(c.cachedEpochTime))
+	ch <- prometheus.MustNewConstMetric(c.epochAgeSeconds, prometheus.GaugeValue, age.Seconds())
+}
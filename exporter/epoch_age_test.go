@@ -0,0 +1,26 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEpochAge(t *testing.T) {
+	// 1500 slots of 400ms into the epoch, fetched 2 seconds ago
+	if age := epochAge(1500, 2*time.Second); age != 602*time.Second {
+		t.Errorf("Expected epoch age of 602s, got %s", age)
+	}
+	if age := epochAge(0, 0); age != 0 {
+		t.Errorf("Expected epoch age of 0 at the first slot, got %s", age)
+	}
+}
+
+func TestCollectEpochAge(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	// the test epoch info is at slot index 10, i.e. 4 seconds into the epoch
+	ms := collectMetrics(t, NewSolanaCollector(newTestConfig(srv.URL)))["solana_epoch_age_seconds"]
+	if len(ms) != 1 || ms[0].GetGauge().GetValue() < 4 || ms[0].GetGauge().GetValue() > 5 {
+		t.Errorf("Expected epoch age of about 4s, got %v", ms)
+	}
+}
@@ -0,0 +1,37 @@
+package exporter
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// emitEpochBehind exports how many epochs the last vote of validator is behind the current epoch of network,
+// anything but 0 means validator is stuck in the view of a prior epoch
+func (c *solanaCollector) emitEpochBehind(ch chan<- prometheus.Metric, lastVote int64) {
+	epochInfo, err := monitor.GetEpochInfo(c.config, utils.Network)
+	if err != nil {
+		log.Printf("Error while getting network epoch info : %v", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.epochBehind, prometheus.GaugeValue, float64(epochsBehind(lastVote, epochInfo)))
+}
+
+// epochsBehind returns the number of epochs between the epoch of the given slot and the current epoch. The epoch
+// of the slot is derived from the first slot of the current epoch and the slots per epoch.
+func epochsBehind(slot int64, epochInfo types.EpochInfo) int64 {
+	info := epochInfo.Result
+	epochStart := info.AbsoluteSlot - info.SlotIndex
+	if slot >= epochStart || info.SlotsInEpoch <= 0 {
+		return 0
+	}
+	behind := (epochStart-slot-1)/info.SlotsInEpoch + 1
+	if behind > info.Epoch {
+		return info.Epoch
+	}
+	return behind
+}
@@ -0,0 +1,32 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestEpochsBehind(t *testing.T) {
+	// epoch 100 starts at slot 43200000
+	var info types.EpochInfo
+	info.Result.Epoch = 100
+	info.Result.AbsoluteSlot = 43210000
+	info.Result.SlotIndex = 10000
+	info.Result.SlotsInEpoch = 432000
+
+	for _, tc := range []struct {
+		lastVote int64
+		behind   int64
+	}{
+		{43209990, 0}, // voting in current epoch
+		{43200000, 0}, // first slot of current epoch
+		{43199999, 1}, // last slot of previous epoch
+		{42768000, 1}, // first slot of previous epoch
+		{42767999, 2}, // last slot of epoch 98
+		{0, 100},      // never voted
+	} {
+		if behind := epochsBehind(tc.lastVote, info); behind != tc.behind {
+			t.Errorf("Expected last vote %d to be %d epochs behind, got %d", tc.lastVote, tc.behind, behind)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package exporter
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// crossedEpochBoundary returns whether the given slot is past the last slot of the epoch of the epoch info, i.e.
+// the epoch changed between fetching the epoch info and the slot
+func crossedEpochBoundary(epochInfo *types.EpochInfo, slot int64) bool {
+	next := epochInfo.Result.AbsoluteSlot - epochInfo.Result.SlotIndex + epochInfo.Result.SlotsInEpoch
+	return slot >= next
+}
+
+// emitEpochBoundary exports whether the epoch changed between the epoch info the scrape started with and the
+// current slot fetched later in it. The metrics of such a scrape mix both epochs, so the cached epoch info is
+// dropped for the next scrape to fetch the new epoch.
+func (c *solanaCollector) emitEpochBoundary(ch chan<- prometheus.Metric, epochInfo *types.EpochInfo, slot int64) {
+	crossed := crossedEpochBoundary(epochInfo, slot)
+	value := float64(0)
+	if crossed {
+		value = 1
+		log.Printf("Epoch %d ended during the scrape at slot %d, fetching the epoch info again on the next scrape", epochInfo.Result.Epoch, slot)
+		c.cachedEpochInfo = nil
+	}
+	ch <- prometheus.MustNewConstMetric(c.scrapeEpochBoundary, prometheus.GaugeValue, value)
+}
@@ -0,0 +1,34 @@
+package exporter
+
+import (
+	"testing"
+)
+
+func TestCollectEpochBoundary(t *testing.T) {
+	// the test epoch info is at slot 1010 with slot index 10, so the next epoch starts at slot 433000
+	for _, tc := range []struct {
+		slot     string
+		expected float64
+	}{
+		{`1010`, 0},
+		{`432999`, 0},
+		// the epoch changed between fetching the epoch info and the current slot
+		{`433000`, 1},
+	} {
+		results := testRPCResults()
+		results["getSlot"] = tc.slot
+		srv := newTestRPCServer(t, results)
+		c := NewSolanaCollector(newTestConfig(srv.URL))
+
+		ms := collectMetrics(t, c)["solana_scrape_epoch_boundary"]
+		if len(ms) != 1 {
+			t.Fatalf("Expected 1 scrape epoch boundary metric at slot %s, got %d", tc.slot, len(ms))
+		}
+		if got := ms[0].GetGauge().GetValue(); got != tc.expected {
+			t.Errorf("Expected scrape epoch boundary %v at slot %s, got %v", tc.expected, tc.slot, got)
+		}
+		if cached := c.cachedEpochInfo != nil; cached != (tc.expected == 0) {
+			t.Errorf("Expected the epoch info to be cached %v after a scrape at slot %s", tc.expected == 0, tc.slot)
+		}
+	}
+}
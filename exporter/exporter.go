@@ -5,6 +5,9 @@ import (
 	"log"
 	"math"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -23,9 +26,27 @@ const (
 
 // solanaCollector respresents a set of solana metrics
 type solanaCollector struct {
-	config                    *config.Config
-	totalValidatorsDesc       *prometheus.Desc
-	validatorActivatedStake   *prometheus.Desc
+	config *config.Config
+	// mu serializes concurrent scrapes e.g. from multiple prometheus servers, as Collect
+	// reads and updates the caches and alerting state of the collector
+	mu sync.Mutex
+	// metrics of the last scrape and when it ended, exported again by LastScrape
+	lastScrapeMu  sync.Mutex
+	lastScrape    []prometheus.Metric
+	lastScrapedAt time.Time
+
+	totalValidatorsDesc     *prometheus.Desc
+	validatorActivatedStake *prometheus.Desc
+	// stake delegated to validator which is activating and deactivating in the current epoch, cached between scrapes
+	activatingStake        *prometheus.Desc
+	deactivatingStake      *prometheus.Desc
+	nextEpochStake         *prometheus.Desc
+	stakeActivation        *stakeActivation
+	deactivationAlertEpoch int64
+	// whether a stake account of a configured foundation authority delegates to validator
+	hasFoundationStake *prometheus.Desc
+	// activated stake under its name without unit, nil unless legacy metric names are enabled
+	legacyActivatedStake      *prometheus.Desc
 	validatorLastVote         *prometheus.Desc
 	validatorRootSlot         *prometheus.Desc
 	validatorDelinquent       *prometheus.Desc
@@ -57,144 +78,482 @@ type solanaCollector struct {
 	blockTimeDiff      *prometheus.Desc
 	voteAccBalance     *prometheus.Desc
 	identityAccBalance *prometheus.Desc
-	lastEpoch          *int64
+	// time taken by the last scrape
+	scrapeDuration *prometheus.Desc
+	// estimated time until validator becomes delinquent from the trend of its vote lag
+	secondsToDelinquency       *prometheus.Desc
+	voteLagTrend               lagTrend
+	delinquencyForecastAlerted bool
+	// progress of the node catching up with the cluster, e.g. after a restart, and the catch up which is alerted
+	// once validator votes again, tracked by WatchSlots
+	catchup        catchupTracker
+	catchupPending *catchup
+	// voting status of validator in the last scrape, set by Collect and read by WatchSlots
+	lastVotingStatus atomic.Value
+	// number of scrapes by result, counted by Collect
+	scrapesTotal *prometheus.Desc
+	scrapes      map[string]float64
+	// average number of slots the votes of validator land behind the cluster
+	voteLatency        *prometheus.Desc
+	voteLatencies      []int64
+	voteLatencyAlerted bool
+	// estimated APY of and rewards paid to delegators, computed once per epoch from the inflation reward of previous epoch
+	estimatedAPY      *prometheus.Desc
+	apy               float64
+	apyOK             bool
+	apyEpoch          int64
+	delegatorRewards  *prometheus.Desc
+	delegatorReward   float64
+	delegatorRewardOK bool
+	// whether validator is in the active set i.e., has activated stake
+	validatorActive *prometheus.Desc
+	lastActive      *bool
+	// activated stake in SOL of the last scrape to alert on stake changes
+	lastActivatedStake *float64
+	// whether epoch info could be fetched in the last scrape
+	epochInfoAvailable *prometheus.Desc
+	// estimated time since the current epoch began
+	epochAgeSeconds *prometheus.Desc
+	// next leader slot of validator and the estimated time until it
+	nextLeaderSlot    *prometheus.Desc
+	nextLeaderSlotETA *prometheus.Desc
+	lastEpoch         *int64
 	// Cache fields to reduce redundant API calls
-	cachedEpochInfo    *types.EpochInfo
-	cachedEpochTime    time.Time
-	cachedVoteAccounts *types.GetVoteAccountsResponse
-	cachedVoteAccTime  time.Time
+	cachedEpochInfo *types.EpochInfo
+	cachedEpochTime time.Time
+	// whether the epoch changed during the scrape
+	scrapeEpochBoundary *prometheus.Desc
+	// slots between the processed and finalized slot of network
+	finalizationLag        *prometheus.Desc
+	finalizationLagAlerted bool
+	// cluster nodes are cached longer as the list is large and changes slowly
+	cachedClusterNodes     *types.ClustrNode
+	cachedClusterNodesTime time.Time
+	// number of cluster nodes per software version
+	clusterVersions *prometheus.Desc
+	// whether the version of the node meets the required version, and the severity of the last upgrade alert
+	versionUpgradeReady *prometheus.Desc
+	upgradeAlerted      string
+	// activated features the version of the node likely lacks, per the configured minimum version of each feature
+	missingActivatedFeatures *prometheus.Desc
+	featureActivations       *featureActivations
+	missingFeaturesAlerted   bool
+	// whether the gossip, tpu and rpc ports of the node are reachable as probed by WatchPorts, and which critical
+	// ones were alerted
+	nodePortReachable *prometheus.Desc
+	portProber        *portProber
+	portAlerted       map[string]bool
+	// which tpu addresses the node advertises in gossip
+	tpuAddressAdvertised *prometheus.Desc
+	tpuForwardingAlerted bool
+	// whether vote credits of validator dropped within an epoch, indicating a possible restart
+	possibleRestart *prometheus.Desc
+	// when validator last produced a block, tracked by WatchSlots
+	lastBlock           blockTracker
+	blockOverdueAlerted bool
+	// produced blocks of the most recent epochs, recorded by WatchSlots
+	blockHistory blockHistory
+	// vote cost of the epoch estimated from identity balance, tracked by WatchSlots
+	voteCost        voteCostTracker
+	voteCostAlerted bool
+	// rent margins of identity and vote accounts, tracked by WatchSlots
+	rentCheckedAt     time.Time
+	rentMarginAlerted map[string]bool
+	// average commission of network vote accounts to benchmark ours
+	networkAvgCommission *prometheus.Desc
+	// median commission of network vote accounts and how far ours is above it
+	networkMedianCommission *prometheus.Desc
+	commissionVsMedian      *prometheus.Desc
+	// vote credits earned by validator per minute and compared to the previous scrape and last epoch
+	voteCreditsRate    *prometheus.Desc
+	epochCreditsDelta  *prometheus.Desc
+	lastCredits        creditsSample
+	creditsRateAlerted bool
+	// whether validator is voting on a minority fork
+	onMinorityFork      *prometheus.Desc
+	forkLastVote        int64
+	forkScrapes         int
+	minorityForkAlerted bool
+	// whether the identity of validator is suspected to run on more than one node
+	identityConflictDesc    *prometheus.Desc
+	identityConflict        identityConflict
+	identityConflictAlerted bool
+	// whether the configured vote key is the vote account actively voting for the identity of validator
+	voteKeyActive          *prometheus.Desc
+	voteKeyMismatchAlerted bool
+	// epochs the last vote of validator is behind the current epoch of network
+	epochBehind *prometheus.Desc
+	// slots the last vote of validator is behind the current slot of the validator rpc
+	localVoteLag *prometheus.Desc
+	// lowest slot whose block is still in the ledger of the validator rpc
+	firstAvailableBlock *prometheus.Desc
+	// rank of validator by vote credits of the epoch and places it has dropped within the epoch
+	voteCreditsRank      *prometheus.Desc
+	voteCreditsRankDelta *prometheus.Desc
+	creditsRank          rankTracker
+	creditsRankAlerted   bool
+	// whether each alert channel is enabled in config
+	alertChannelEnabled *prometheus.Desc
+	cachedVoteAccounts  *types.GetVoteAccountsResponse
+	cachedVoteAccTime   time.Time
+	// last good metrics of each metric group, re-exported on rpc errors when on_error is hold_last
+	lastGood map[string][]prometheus.Metric
+	// delinquency and not voting alerts are suppressed until this time after startup
+	graceUntil time.Time
+	// the days on which each regular status alert timing fired
+	statusAlerts statusAlertSchedule
+	// time at which validator became delinquent, zero if it is not delinquent
+	delinquentSince   time.Time
+	delinquentSeconds *prometheus.Desc
+	// leader schedule of validator in absolute slots, cached per epoch
+	leaderSchedule      []int64
+	leaderScheduleEpoch int64
+	// whether validator is in the leader schedule of the next epoch, checked once per epoch
+	inNextLeaderSchedule     *prometheus.Desc
+	nextScheduleEpoch        int64
+	nextScheduleHasValidator bool
+	nextScheduleAlerted      bool
+}
+
+// txCountLabels returns the variable labels of solana_tx_count, the formatted count is only added when configured
+func txCountLabels(cfg *config.Config) []string {
+	if cfg.Prometheus.TxCountLabel == "compact" {
+		return []string{"solana_tx_count"}
+	}
+	return nil
 }
 
 // NewSolanaCollector exports solana collector metrics to prometheus
 func NewSolanaCollector(cfg *config.Config) *solanaCollector {
+	// static labels of config are attached to every metric of the collector
+	labels := prometheus.Labels(cfg.MetricLabels())
 	return &solanaCollector{
-		config: cfg,
+		config:     cfg,
+		lastGood:   make(map[string][]prometheus.Metric),
+		graceUntil: time.Now().Add(startupGracePeriod(cfg)),
+		portProber: newPortProber(),
 		totalValidatorsDesc: prometheus.NewDesc(
 			"solana_active_validators",
-			"Total number of active validators by state",
-			[]string{"state"}, nil),
+			"Number of vote accounts of the network by state, current or delinquent",
+			[]string{"state"}, labels),
 		validatorActivatedStake: prometheus.NewDesc(
+			"solana_validator_activated_stake_sol",
+			"Activated stake of validator in SOL",
+			[]string{"votekey", "pubkey"}, labels),
+		legacyActivatedStake: legacyDesc(cfg,
 			"solana_validator_activated_stake",
-			"Activated stake per validator",
-			[]string{"votekey", "pubkey"}, nil),
+			"Activated stake of validator in SOL",
+			[]string{"votekey", "pubkey"}, labels),
+		activatingStake: prometheus.NewDesc(
+			"solana_validator_activating_stake_sol",
+			"Stake in SOL delegated to validator which is activating in the current epoch and active from the next one",
+			nil, labels),
+		deactivatingStake: prometheus.NewDesc(
+			"solana_validator_deactivating_stake_sol",
+			"Stake in SOL delegated to validator which is deactivating in the current epoch and inactive from the next one",
+			nil, labels),
+		nextEpochStake: prometheus.NewDesc(
+			"solana_validator_next_epoch_stake",
+			"Effective stake of validator in SOL in the next epoch, the activated stake plus activating minus deactivating stake",
+			nil, labels),
+		hasFoundationStake: prometheus.NewDesc(
+			"solana_validator_has_foundation_stake",
+			"Whether a stake account of a configured foundation authority delegates to validator, 1 if it does else 0",
+			nil, labels),
 		validatorLastVote: prometheus.NewDesc(
 			"solana_validator_last_vote",
-			"Last voted slot per validator",
-			[]string{"votekey", "pubkey"}, nil),
+			"Last slot voted on by validator",
+			[]string{"votekey", "pubkey"}, labels),
 		validatorRootSlot: prometheus.NewDesc(
 			"solana_validator_root_slot",
-			"Root slot per validator",
-			[]string{"votekey", "pubkey"}, nil),
+			"Root slot of validator",
+			[]string{"votekey", "pubkey"}, labels),
 		validatorDelinquent: prometheus.NewDesc(
 			"solana_validator_delinquent",
-			"Whether a validator is delinquent",
-			[]string{"votekey", "pubkey"}, nil),
+			"Whether validator is delinquent, 1 if delinquent else 0",
+			[]string{"votekey", "pubkey"}, labels),
 		solanaVersion: prometheus.NewDesc(
 			"solana_node_version",
-			"Node version of solana",
-			[]string{"version"}, nil),
+			"Solana version of node in the version label, always 1",
+			[]string{"version"}, labels),
 		accountBalance: prometheus.NewDesc( // check using or not
 			"solana_account_balance",
-			"Solana identity account balance",
-			[]string{"solana_acc_balance"}, nil),
+			"Unused, see solana_account_balance_sol for the identity account balance",
+			[]string{"solana_acc_balance"}, labels),
 		slotLeader: prometheus.NewDesc(
 			"solana_slot_leader",
-			"Current slot leader",
-			[]string{"solana_slot_leader"}, nil),
+			"Leader of the current slot in the solana_slot_leader label, always 1",
+			[]string{"solana_slot_leader"}, labels),
 		currentSlot: prometheus.NewDesc(
 			"solana_current_slot",
-			"Current slot height",
-			[]string{"solana_current_slot"}, nil,
+			"Current slot of node",
+			[]string{"solana_current_slot"}, labels,
 		),
 		blockTime: prometheus.NewDesc(
 			"solana_block_time",
-			"Current block time.",
-			[]string{"solana_block_time"}, nil,
+			"Unused, see solana_network_confirmed_time for the block time",
+			[]string{"solana_block_time"}, labels,
 		),
 		commission: prometheus.NewDesc(
 			"solana_val_commission",
-			"Solana validator current commission.",
-			[]string{"solana_val_commission"}, nil,
+			"Commission of validator in percent",
+			[]string{"solana_val_commission"}, labels,
 		),
 		delinqentCommission: prometheus.NewDesc(
 			"solana_val_delinquuent_commission",
-			"Solana validator delinqent commission.",
-			[]string{"solana_delinquent_commission"}, nil,
+			"Commission of validator in percent while it is delinquent",
+			[]string{"solana_delinquent_commission"}, labels,
 		),
 		validatorVote: prometheus.NewDesc(
 			"solana_vote_account",
-			"whether the vote account is staked for this epoch",
-			[]string{"state"}, nil,
+			"Whether vote account of validator is staked for this epoch by state, 1 if staked else 0",
+			[]string{"state"}, labels,
 		),
 		statusAlertCount: prometheus.NewDesc(
 			"solana_val_alert_count",
-			"Count of alerts about validator status alerting",
-			[]string{"alert_count"}, nil,
+			"Number of regular validator status alerts sent, whether one was already sent in the alert_count label",
+			[]string{"alert_count"}, labels,
 		),
 		ipAddress: prometheus.NewDesc(
 			"solana_ip_address",
-			"IP Address from clustrnode information, gossip",
-			[]string{"ip_address"}, nil,
+			"Unused, gossip IP address of validator from cluster nodes",
+			[]string{"ip_address"}, labels,
 		),
 		txCount: prometheus.NewDesc(
 			"solana_tx_count",
-			"solana transaction count",
-			[]string{"solana_tx_count"}, nil,
+			"Number of transactions processed by the network since genesis",
+			txCountLabels(cfg), labels,
 		),
 		netVoteHeight: prometheus.NewDesc(
 			"solana_network_vote_height",
-			"solana network vote height",
-			[]string{"solana_network_vote_height"}, nil,
+			"Last slot voted on by validator as seen by network",
+			[]string{"solana_network_vote_height"}, labels,
 		),
 		valVoteHeight: prometheus.NewDesc(
 			"solana_validator_vote_height",
-			"solana validator vote height",
-			[]string{"solana_validator_vote_height"}, nil,
+			"Last slot voted on by validator",
+			[]string{"solana_validator_vote_height"}, labels,
 		),
 		voteHeightDiff: prometheus.NewDesc(
 			"solana_vote_height_diff",
-			"solana vote height difference of validator and network",
-			[]string{"solana_vote_height_diff"}, nil,
+			"Difference of network and validator vote height in slots",
+			[]string{"solana_vote_height_diff"}, labels,
 		),
 		valVotingStatus: prometheus.NewDesc(
 			"solana_val_status",
-			"solana validator voting status i.e., voting or jailed.",
-			[]string{"solana_val_status"}, nil,
+			"Whether validator is voting, 1 if voting else 0 (jailed), status in the solana_val_status label",
+			[]string{"solana_val_status"}, labels,
 		),
 		voteCredits: prometheus.NewDesc(
 			"solana_validator_vote_credits",
-			"solana validator vote credits of previous and current epoch.",
-			[]string{"type"}, nil,
+			"Vote credits earned by validator in the current and previous epoch by type",
+			[]string{"type"}, labels,
 		),
 		networkVoteCredits: prometheus.NewDesc(
 			"solana_network_vote_credits",
-			"solana network average vote credits of previous and current epoch.",
-			[]string{"type"}, nil,
+			"Average vote credits earned by current vote accounts of network in the current and previous epoch by type",
+			[]string{"type"}, labels,
 		),
 		networkBlockTime: prometheus.NewDesc(
 			"solana_network_confirmed_time",
-			"Confirmed Block time of network",
-			[]string{"solana_network_confirmed_time"}, nil,
+			"Unused, confirmed block time of network",
+			[]string{"solana_network_confirmed_time"}, labels,
 		),
 		validatorBlockTime: prometheus.NewDesc(
 			"solana_val_confirmed_time",
-			"Confirmed Block time of validator",
-			[]string{"solana_val_confirmed_time"}, nil,
+			"Unused, confirmed block time of validator",
+			[]string{"solana_val_confirmed_time"}, labels,
 		),
 		blockTimeDiff: prometheus.NewDesc(
 			"solana_confirmed_blocktime_diff",
-			"Block time difference of network and validator",
-			[]string{"solana_confirmed_blocktime_diff"}, nil,
+			"Unused, difference of network and validator confirmed block time in seconds",
+			[]string{"solana_confirmed_blocktime_diff"}, labels,
 		),
 		voteAccBalance: prometheus.NewDesc(
 			"solana_vote_account_balance",
-			"Vote account balance",
-			[]string{"solana_vote_acc_bal"}, nil,
+			"Unused, balance of vote account of validator",
+			[]string{"solana_vote_acc_bal"}, labels,
 		),
 		identityAccBalance: prometheus.NewDesc(
 			"solana_identity_account_balance",
-			"Identity account balance",
-			[]string{"solana_identity_acc_bal"}, nil,
+			"Unused, see solana_account_balance_sol for the identity account balance",
+			[]string{"solana_identity_acc_bal"}, labels,
+		),
+		scrapeDuration: prometheus.NewDesc(
+			"solana_scrape_duration_seconds",
+			"Time taken by the last scrape of solana metrics in seconds",
+			nil, labels,
+		),
+		secondsToDelinquency: prometheus.NewDesc(
+			"solana_validator_estimated_seconds_to_delinquency",
+			"Estimated seconds until the increasing vote lag of validator makes it delinquent, not exported while the lag isn't increasing",
+			nil, labels,
+		),
+		scrapesTotal: prometheus.NewDesc(
+			"solana_scrapes_total",
+			"Number of scrapes of solana metrics by result, success, partial when some rpc calls failed or failure when all did",
+			[]string{"result"}, labels,
+		),
+		scrapes: map[string]float64{scrapeSuccess: 0, scrapePartial: 0, scrapeFailure: 0},
+		voteLatency: prometheus.NewDesc(
+			"solana_validator_vote_latency_slots",
+			"Average number of slots the last vote of validator is behind the most recent vote of the cluster over recent scrapes",
+			nil, labels,
+		),
+		estimatedAPY: prometheus.NewDesc(
+			"solana_validator_estimated_apy",
+			"Estimated APY in percent of delegators of validator from the inflation rewards of previous epoch and commission",
+			nil, labels,
+		),
+		delegatorRewards: prometheus.NewDesc(
+			"solana_delegator_rewards_lamports",
+			"Inflation rewards in lamports paid to delegators of validator in previous epoch, derived from the commission reward",
+			nil, labels,
+		),
+		delinquentSeconds: prometheus.NewDesc(
+			"solana_validator_delinquent_seconds",
+			"Number of seconds validator has been delinquent, 0 if it is not delinquent",
+			nil, labels,
+		),
+		clusterVersions: prometheus.NewDesc(
+			"solana_cluster_versions",
+			"Number of cluster nodes running each software version, bounded to the most common versions",
+			[]string{"version"}, labels,
+		),
+		scrapeEpochBoundary: prometheus.NewDesc(
+			"solana_scrape_epoch_boundary",
+			"Whether the epoch changed during the scrape, so its metrics may mix both epochs (1) or not (0)",
+			nil, labels,
+		),
+		finalizationLag: prometheus.NewDesc(
+			"solana_finalization_lag_slots",
+			"Number of slots the finalized slot of network is behind its processed slot",
+			nil, labels,
+		),
+		versionUpgradeReady: prometheus.NewDesc(
+			"solana_version_upgrade_ready",
+			"Whether the version of the node meets the configured required version (1) or not (0)",
+			nil, labels,
+		),
+		missingActivatedFeatures: prometheus.NewDesc(
+			"solana_missing_activated_features",
+			"Number of activated features which the version of the node likely lacks per their configured minimum version",
+			nil, labels,
+		),
+		tpuAddressAdvertised: prometheus.NewDesc(
+			"solana_node_tpu_address_advertised",
+			"Whether the node advertises the tpu, tpu_quic, tpu_forwards or tpu_forwards_quic address in gossip (1) or not (0)",
+			[]string{"address"}, labels,
+		),
+		nodePortReachable: prometheus.NewDesc(
+			"solana_node_port_reachable",
+			"Whether the gossip, tpu or rpc port advertised by the node is reachable from the monitor host (1) or not (0)",
+			[]string{"port"}, labels,
+		),
+		voteCreditsRate: prometheus.NewDesc(
+			"solana_validator_vote_credits_rate",
+			"Vote credits earned by validator per minute since the previous scrape of the same epoch",
+			nil, labels,
+		),
+		possibleRestart: prometheus.NewDesc(
+			"solana_validator_possible_restart",
+			"Whether vote credits of validator dropped since the previous scrape of the same epoch, 1 if they did else 0",
+			nil, labels,
+		),
+		networkAvgCommission: prometheus.NewDesc(
+			"solana_network_avg_commission",
+			"Average commission in percent of current vote accounts of the network, unweighted or weighted by activated stake",
+			[]string{"type"}, labels,
+		),
+		networkMedianCommission: prometheus.NewDesc(
+			"solana_network_median_commission",
+			"Median commission in percent of current vote accounts of the network",
+			nil, labels,
+		),
+		commissionVsMedian: prometheus.NewDesc(
+			"solana_validator_commission_vs_median",
+			"Commission of validator minus the median commission of the network in percentage points",
+			nil, labels,
+		),
+		epochCreditsDelta: prometheus.NewDesc(
+			"solana_validator_epoch_credits_delta",
+			"Vote credits earned since the previous scrape (type scrape) and earned this epoch minus earned in last epoch (type epoch)",
+			[]string{"type"}, labels,
+		),
+		onMinorityFork: prometheus.NewDesc(
+			"solana_validator_on_minority_fork",
+			"Whether validator keeps voting while its root slot diverges from the finalized slot of network, 1 if on a minority fork else 0",
+			nil, labels,
+		),
+		identityConflictDesc: prometheus.NewDesc(
+			"solana_validator_identity_conflict",
+			"Whether the identity of validator is suspected to run on more than one node, i.e. it votes on more than one vote account or its last vote jumps backwards, 1 if suspected else 0",
+			nil, labels,
+		),
+		voteKeyActive: prometheus.NewDesc(
+			"solana_vote_key_active",
+			"Whether the configured vote key is the vote account earning credits for the identity of validator in the current epoch, 1 if it is else 0",
+			nil, labels,
+		),
+		epochBehind: prometheus.NewDesc(
+			"solana_validator_epoch_behind",
+			"Number of epochs the epoch of the last vote of validator is behind the current epoch of network, nonzero if validator is stuck in a prior epoch",
+			nil, labels,
+		),
+		localVoteLag: prometheus.NewDesc(
+			"solana_validator_local_vote_lag",
+			"Number of slots the last vote of validator is behind the current slot of the validator rpc, large with a healthy rpc if validator is not voting",
+			nil, labels,
+		),
+		firstAvailableBlock: prometheus.NewDesc(
+			"solana_first_available_block",
+			"Slot of the lowest confirmed block which has not been purged from the ledger of the validator rpc, older blocks and rewards can't be queried",
+			nil, labels,
+		),
+		voteCreditsRank: prometheus.NewDesc(
+			"solana_validator_vote_credits_rank",
+			"Rank of validator among the current vote accounts by vote credits earned this epoch, 1 for the most credits",
+			nil, labels,
+		),
+		voteCreditsRankDelta: prometheus.NewDesc(
+			"solana_validator_vote_credits_rank_delta",
+			"Number of places the vote credits rank of validator has dropped since its best rank this epoch, 0 if at its best",
+			nil, labels,
+		),
+		alertChannelEnabled: prometheus.NewDesc(
+			"solana_alert_channel_enabled",
+			"Whether the alert channel is enabled in config, 1 if enabled else 0",
+			[]string{"channel"}, labels,
+		),
+		validatorActive: prometheus.NewDesc(
+			"solana_validator_active",
+			"Whether validator is in the active set i.e., its vote account has activated stake, 1 if active else 0",
+			nil, labels,
+		),
+		epochAgeSeconds: prometheus.NewDesc(
+			"solana_epoch_age_seconds",
+			"Estimated time since the current epoch began in seconds, from the slot index of the epoch and the average slot time",
+			nil, labels,
+		),
+		epochInfoAvailable: prometheus.NewDesc(
+			"solana_epoch_info_available",
+			"Whether epoch info could be fetched in the last scrape, 1 if available else 0",
+			nil, labels,
+		),
+		nextLeaderSlot: prometheus.NewDesc(
+			"solana_validator_next_leader_slot",
+			"Next leader slot of validator in current epoch",
+			nil, labels,
+		),
+		nextLeaderSlotETA: prometheus.NewDesc(
+			"solana_validator_next_leader_eta_seconds",
+			"Estimated time until the next leader slot of validator in seconds",
+			nil, labels,
+		),
+		inNextLeaderSchedule: prometheus.NewDesc(
+			"solana_validator_in_next_leader_schedule",
+			"Whether validator is in the leader schedule of the next epoch, 1 if it is else 0",
+			nil, labels,
 		),
 	}
 
@@ -211,7 +570,7 @@ func (c *solanaCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.delinqentCommission
 	ch <- c.validatorVote
 	ch <- c.ipAddress
-	// ch <- c.StatusAlertCount
+	ch <- c.statusAlertCount
 	ch <- c.txCount
 	ch <- c.netVoteHeight
 	ch <- c.valVoteHeight
@@ -222,6 +581,54 @@ func (c *solanaCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.blockTimeDiff
 	ch <- c.voteAccBalance
 	ch <- c.identityAccBalance
+	ch <- c.validatorActivatedStake
+	ch <- c.validatorLastVote
+	ch <- c.validatorRootSlot
+	ch <- c.validatorDelinquent
+	ch <- c.voteCredits
+	ch <- c.networkVoteCredits
+	ch <- c.scrapeDuration
+	ch <- c.scrapesTotal
+	ch <- c.secondsToDelinquency
+	ch <- c.epochInfoAvailable
+	ch <- c.epochAgeSeconds
+	ch <- c.validatorActive
+	ch <- c.voteLatency
+	ch <- c.estimatedAPY
+	ch <- c.delegatorRewards
+	ch <- c.delinquentSeconds
+	ch <- c.clusterVersions
+	ch <- c.versionUpgradeReady
+	ch <- c.missingActivatedFeatures
+	ch <- c.scrapeEpochBoundary
+	ch <- c.finalizationLag
+	ch <- c.nodePortReachable
+	ch <- c.tpuAddressAdvertised
+	ch <- c.alertChannelEnabled
+	ch <- c.onMinorityFork
+	ch <- c.epochBehind
+	ch <- c.localVoteLag
+	ch <- c.firstAvailableBlock
+	ch <- c.voteCreditsRank
+	ch <- c.voteCreditsRankDelta
+	ch <- c.identityConflictDesc
+	ch <- c.voteKeyActive
+	ch <- c.voteCreditsRate
+	ch <- c.epochCreditsDelta
+	ch <- c.networkAvgCommission
+	ch <- c.networkMedianCommission
+	ch <- c.commissionVsMedian
+	ch <- c.possibleRestart
+	ch <- c.nextLeaderSlot
+	ch <- c.nextLeaderSlotETA
+	ch <- c.inNextLeaderSchedule
+	ch <- c.activatingStake
+	ch <- c.deactivatingStake
+	ch <- c.nextEpochStake
+	ch <- c.hasFoundationStake
+	if c.legacyActivatedStake != nil {
+		ch <- c.legacyActivatedStake
+	}
 }
 
 // mustEmitMetrics gets the data from Current and Deliquent validator vote accounts and export metrics of validator Vote account to prometheus.
@@ -239,31 +646,50 @@ func (c *solanaCollector) Describe(ch chan<- *prometheus.Desc) {
 // 9. VOte Height difference of Validator and Network
 // 10. Validator Vote Credits
 // 11. Deliquent validator commision
-// 12. Deliquent validatot vote account whether it voting or not and send alerts
+// 12. Deliquent validatot vote account whether it voting or not and send alerts, how long it has been delinquent
+// 13. Whether validator is in the active set and send alert when it falls out of it
+// 14. Validator vote latency and send alert when it exceeds the threshold
+// 15. Estimated APY of delegators
+// 16. Whether validator is on a minority fork and send alert when it switches to one
+// 17. Vote credits rate of validator and send alert when it drops below the floor
+// 18. Vote credits of validator compared to the previous scrape and last epoch
+// 19. Average and median commission of network and the spread of ours to the median
+// 20. Whether validator possibly restarted and send alert when it did
+// 21. Epochs the last vote of validator is behind the network
+// 22. Activating and deactivating stake of validator, whether it has foundation stake and send alert when a large stake is deactivating
+// 23. Whether the identity of validator runs on more than one node and send alert when it does
+// 24. Vote credits rank of validator and send alert when it drops within the epoch
+// 25. Whether the configured vote key is the active vote account of validator and send alert when it isn't
 func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response types.GetVoteAccountsResponse) {
 	ch <- prometheus.MustNewConstMetric(c.totalValidatorsDesc, prometheus.GaugeValue,
 		float64(len(response.Result.Delinquent)), "delinquent")
 	ch <- prometheus.MustNewConstMetric(c.totalValidatorsDesc, prometheus.GaugeValue,
 		float64(len(response.Result.Current)), "current")
 
+	// validator is in the active set when its vote account has activated stake
+	var active bool
 	for _, account := range append(response.Result.Current, response.Result.Delinquent...) {
 		if account.NodePubkey == c.config.ValDetails.PubKey {
+			active = account.ActivatedStake > 0
+			c.alertStakeChange(float64(account.ActivatedStake) / math.Pow(10, 9))
 			// ch <- prometheus.MustNewConstMetric(c.validatorActivatedStake, prometheus.GaugeValue,
 			// 	float64(account.ActivatedStake), account.VotePubkey, account.NodePubkey)
 			ch <- prometheus.MustNewConstMetric(c.validatorLastVote, prometheus.GaugeValue,
 				float64(account.LastVote), account.VotePubkey, account.NodePubkey)
 			ch <- prometheus.MustNewConstMetric(c.validatorRootSlot, prometheus.GaugeValue,
 				float64(account.RootSlot), account.VotePubkey, account.NodePubkey)
+			c.emitMinorityFork(ch, account)
+			c.emitEpochBehind(ch, int64(account.LastVote))
 		}
 	}
 
 	var epochvote float64
 	var valresult float64
 
-	// Get epoch info once and reuse it for all vote accounts
-	_, err := c.getCachedEpochInfo()
+	// Get epoch info once and reuse it for all vote accounts, vote credits are skipped when it is unavailable
+	epochInfo, err := c.getCachedEpochInfo()
 	if err != nil {
-		log.Printf("Error while getting epoch info : %v", err)
+		log.Printf("Error while getting epoch info, skipping vote credits : %v", err)
 	}
 
 	// Get network vote info from the response data we already have
@@ -277,10 +703,16 @@ func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response
 
 	var runningCurrentCredits, runningPreviousCredits float64
 	var currentCreditsCount, previousCreditsCount int64
+	commissions := make([]int64, 0, len(response.Result.Current))
+	ownCommission := int64(-1)
 	// current vote account information
 	for _, vote := range response.Result.Current {
-		cCredits, pCredits := c.calcualteEpochVoteCredits(vote.EpochCredits)
-		if cCredits != 0 && pCredits != 0 {
+		commissions = append(commissions, vote.Commission)
+		var cCredits, pCredits float64
+		if epochInfo != nil {
+			cCredits, pCredits = c.calcualteEpochVoteCredits(vote.EpochCredits)
+		}
+		if cCredits != 0 && pCredits != 0 && c.inNetworkCreditsAverage(vote) {
 			runningCurrentCredits += cCredits
 			runningPreviousCredits += pCredits
 			currentCreditsCount++
@@ -288,6 +720,7 @@ func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response
 		}
 		if vote.NodePubkey == c.config.ValDetails.PubKey {
 			v := strconv.FormatInt(vote.Commission, 10)
+			ownCommission = vote.Commission
 
 			if vote.EpochVoteAccount {
 				epochvote = 1
@@ -305,16 +738,22 @@ func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response
 			stake := float64(vote.ActivatedStake) / math.Pow(10, 9)
 			ch <- prometheus.MustNewConstMetric(c.validatorActivatedStake, prometheus.GaugeValue,
 				stake, vote.VotePubkey, vote.NodePubkey) // store activated stake
+			if c.legacyActivatedStake != nil {
+				ch <- prometheus.MustNewConstMetric(c.legacyActivatedStake, prometheus.GaugeValue,
+					stake, vote.VotePubkey, vote.NodePubkey)
+			}
 
 			// Check weather the validator is voting or not
-			if !vote.EpochVoteAccount && vote.ActivatedStake <= 0 {
-				msg := "Solana validator is NOT VOTING"
-				c.AlertValidatorStatus(msg, ch)
+			if !vote.EpochVoteAccount {
+				if !c.inStartupGrace() {
+					msg := alerter.Localize(c.config, alerter.MsgNotVoting)
+					c.AlertValidatorStatus(msg, alerter.Critical, ch)
+				}
 
 				ch <- prometheus.MustNewConstMetric(c.valVotingStatus, prometheus.GaugeValue, 0, "Jailed")
 			} else {
-				msg := "Solana validator is VOTING"
-				c.AlertValidatorStatus(msg, ch)
+				msg := alerter.Localize(c.config, alerter.MsgVoting)
+				c.AlertValidatorStatus(msg, alerter.Info, ch)
 
 				ch <- prometheus.MustNewConstMetric(c.valVotingStatus, prometheus.GaugeValue, 1, "Voting")
 			}
@@ -325,15 +764,46 @@ func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response
 			ch <- prometheus.MustNewConstMetric(c.voteHeightDiff, prometheus.GaugeValue, diff, "vote height difference")
 
 			// calcualte vote credits
-			ch <- prometheus.MustNewConstMetric(c.voteCredits, prometheus.GaugeValue, float64(cCredits), "current")
-			ch <- prometheus.MustNewConstMetric(c.voteCredits, prometheus.GaugeValue, float64(pCredits), "previous")
+			if epochInfo != nil {
+				ch <- prometheus.MustNewConstMetric(c.voteCredits, prometheus.GaugeValue, float64(cCredits), "current")
+				ch <- prometheus.MustNewConstMetric(c.voteCredits, prometheus.GaugeValue, float64(pCredits), "previous")
+				c.emitVoteCreditsRate(ch, epochInfo.Result.Epoch, int64(cCredits), time.Now())
+				c.emitEpochCreditsDelta(ch, vote.EpochCredits, epochInfo.Result.Epoch)
+				c.emitCreditsRank(ch, response.Result.Current, vote.VotePubkey, epochInfo.Result.Epoch)
+
+				c.emitEstimatedAPY(ch, vote, epochInfo)
+				c.emitStakeActivation(ch, epochInfo.Result.Epoch, stake)
+			}
 		}
 	}
 
-	avgCurrentCredits := runningCurrentCredits / float64(currentCreditsCount)
-	avgPreviousCredits := runningPreviousCredits / float64(previousCreditsCount)
-	ch <- prometheus.MustNewConstMetric(c.networkVoteCredits, prometheus.GaugeValue, avgCurrentCredits, "current")
-	ch <- prometheus.MustNewConstMetric(c.networkVoteCredits, prometheus.GaugeValue, avgPreviousCredits, "previous")
+	if epochInfo != nil && currentCreditsCount > 0 {
+		avgCurrentCredits := runningCurrentCredits / float64(currentCreditsCount)
+		avgPreviousCredits := runningPreviousCredits / float64(previousCreditsCount)
+		ch <- prometheus.MustNewConstMetric(c.networkVoteCredits, prometheus.GaugeValue, avgCurrentCredits, "current")
+		ch <- prometheus.MustNewConstMetric(c.networkVoteCredits, prometheus.GaugeValue, avgPreviousCredits, "previous")
+	}
+
+	if unweighted, weighted, ok := averageCommission(response.Result.Current); ok {
+		ch <- prometheus.MustNewConstMetric(c.networkAvgCommission, prometheus.GaugeValue, unweighted, "unweighted")
+		ch <- prometheus.MustNewConstMetric(c.networkAvgCommission, prometheus.GaugeValue, weighted, "stake_weighted")
+	}
+	if median, ok := medianCommission(commissions); ok {
+		ch <- prometheus.MustNewConstMetric(c.networkMedianCommission, prometheus.GaugeValue, median)
+		if ownCommission >= 0 {
+			ch <- prometheus.MustNewConstMetric(c.commissionVsMedian, prometheus.GaugeValue, float64(ownCommission)-median)
+		}
+	}
+
+	// how long validator has been delinquent, alerts escalate from warning to critical with it
+	var delinquent bool
+	for _, vote := range response.Result.Delinquent {
+		if vote.NodePubkey == c.config.ValDetails.PubKey {
+			delinquent = true
+		}
+	}
+	delinquentFor := c.trackDelinquency(delinquent, time.Now())
+	ch <- prometheus.MustNewConstMetric(c.delinquentSeconds, prometheus.GaugeValue, delinquentFor.Seconds())
 
 	// delinquent vote account information
 	for _, vote := range response.Result.Delinquent {
@@ -352,25 +822,108 @@ func (c *solanaCollector) mustEmitMetrics(ch chan<- prometheus.Metric, response
 			ch <- prometheus.MustNewConstMetric(c.validatorDelinquent, prometheus.GaugeValue,
 				1, vote.VotePubkey, vote.NodePubkey)
 
-			// Send Telegram Alert
-			telegramErr := alerter.SendTelegramAlert(fmt.Sprintf("Your solana validator is in DELINQUENT state"), c.config)
-			if telegramErr != nil {
-				log.Printf("Error while sending vallidator status alert to telegram: %v", telegramErr)
+			if c.inStartupGrace() {
+				log.Printf("Validator is delinquent, alert suppressed during startup grace period")
+				continue
 			}
-
-			// Send Email Alert
-			emailErr := alerter.SendEmailAlert(fmt.Sprintf("Your solana validator is in DELINQUNET state"), c.config)
-			if emailErr != nil {
-				log.Printf("Error while sending validator status alert to email: %v", emailErr)
+			msg := alerter.Localize(c.config, alerter.MsgDelinquent, delinquentFor.Round(time.Second))
+			if delinquentFor < delinquentCriticalAfter {
+				err := alerter.SendAlert(alerter.EventDelinquent, msg, alerter.Warning, c.config)
+				if err != nil {
+					log.Printf("Error while sending validator status alert: %v", err)
+				}
+				continue
 			}
-
-			// Send Slack Alert
-			slackErr := alerter.SendSlackAlert(fmt.Sprintf("Your solana validator is in DELINQUENT state"), c.config)
-			if slackErr != nil {
-				log.Printf("Error while sending validator status alert to slack: %v", slackErr)
+			// once critical, the alert is repeated at the escalation intervals if configured instead of on every scrape
+			if !alerter.Escalating(alerter.EventDelinquent) {
+				err := alerter.SendAlert(alerter.EventDelinquent, msg, alerter.Critical, c.config)
+				if err != nil {
+					log.Printf("Error while sending validator status alert: %v", err)
+				}
+			}
+			if err := alerter.Escalate(alerter.EventDelinquent, msg, true, c.config); err != nil {
+				log.Printf("Error while escalating validator status alert: %v", err)
 			}
 		}
 	}
+	if !delinquent {
+		if err := alerter.Escalate(alerter.EventDelinquent, "", false, c.config); err != nil {
+			log.Printf("Error while escalating validator status alert: %v", err)
+		}
+	}
+
+	var activeValue float64
+	if active {
+		activeValue = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.validatorActive, prometheus.GaugeValue, activeValue)
+	c.alertActiveSet(active)
+	c.emitIdentityConflict(ch, response)
+	c.emitVoteKeyActive(ch, response)
+
+	c.emitVoteLatency(ch, response)
+}
+
+// delinquentCriticalAfter is the duration of delinquency after which delinquency alerts become critical
+const delinquentCriticalAfter = 10 * time.Minute
+
+// trackDelinquency records when validator became delinquent and returns how long it has been delinquent,
+// 0 if it is not delinquent
+func (c *solanaCollector) trackDelinquency(delinquent bool, now time.Time) time.Duration {
+	if !delinquent {
+		c.delinquentSince = time.Time{}
+		return 0
+	}
+	if c.delinquentSince.IsZero() {
+		c.delinquentSince = now
+	}
+	return now.Sub(c.delinquentSince)
+}
+
+// startupGracePeriod returns the configured startup grace period, 0 if not configured or invalid
+func startupGracePeriod(cfg *config.Config) time.Duration {
+	if cfg.AlerterPreferences.StartupGracePeriod == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(cfg.AlerterPreferences.StartupGracePeriod)
+	if err != nil {
+		log.Printf("Invalid startup grace period %q, not suppressing alerts at startup: %v", cfg.AlerterPreferences.StartupGracePeriod, err)
+		return 0
+	}
+	return d
+}
+
+// inStartupGrace reports whether delinquency and not voting alerts are still suppressed after startup,
+// as the validator may momentarily appear delinquent while catching up after a restart
+func (c *solanaCollector) inStartupGrace() bool {
+	return time.Now().Before(c.graceUntil)
+}
+
+// alertActiveSet sends an alert once when the validator falls out of the active set, i.e. its vote
+// account has no activated stake or is not found at all. This is independent of delinquency.
+func (c *solanaCollector) alertActiveSet(active bool) {
+	wasActive := c.lastActive == nil || *c.lastActive
+	c.lastActive = &active
+
+	if !strings.EqualFold(c.config.AlerterPreferences.ActiveSetAlerts, "yes") {
+		return
+	}
+
+	if !active && wasActive {
+		err := alerter.SendAlert(alerter.EventActiveSet, alerter.Localize(c.config, alerter.MsgActiveSet), alerter.Critical, c.config)
+		if err != nil {
+			log.Printf("Error while sending active set alert: %v", err)
+		}
+	}
+	if err := alerter.Escalate(alerter.EventActiveSet, "Active Set Alert : Your solana validator is still out of the active set", !active, c.config); err != nil {
+		log.Printf("Error while escalating active set alert: %v", err)
+	}
+}
+
+// inNetworkCreditsAverage reports whether the vote credits of the vote account are part of the network average,
+// vote accounts below the configured minimum activated stake are left out so tiny validators don't skew it
+func (c *solanaCollector) inNetworkCreditsAverage(vote types.VoteAccount) bool {
+	return float64(vote.ActivatedStake)/math.Pow(10, 9) >= c.config.Prometheus.NetworkCreditsMinStake
 }
 
 // calculateEpochVoteCredits returns epoch credits of vote account
@@ -378,6 +931,7 @@ func (c *solanaCollector) calcualteEpochVoteCredits(credits [][]int64) (float64,
 	epochInfo, err := c.getCachedEpochInfo()
 	if err != nil {
 		log.Printf("Error while getting epoch info : %v", err)
+		return 0, 0
 	}
 
 	epoch := epochInfo.Result.Epoch
@@ -398,49 +952,32 @@ func (c *solanaCollector) calcualteEpochVoteCredits(credits [][]int64) (float64,
 	return float64(currentCredits), float64(previousCredits)
 }
 
-// AlertValidatorStatus sends validator status alerts at respective alert timings.
-func (c *solanaCollector) AlertValidatorStatus(msg string, ch chan<- prometheus.Metric) {
+// AlertValidatorStatus sends validator status alerts of given severity at respective alert timings.
+// An alert timing fires once a day on the first scrape at or within the alert window after it, so
+// a scrape skipping the minute of the timing doesn't miss the alert.
+func (c *solanaCollector) AlertValidatorStatus(msg, severity string, ch chan<- prometheus.Metric) {
 	now := time.Now().UTC()
-	currentTime := now.Format(time.Kitchen)
-
-	var alertsArray []string
-
-	for _, value := range c.config.RegularStatusAlerts.AlertTimings {
-		t, _ := time.Parse(time.Kitchen, value)
-		alertTime := t.Format(time.Kitchen)
+	due := c.statusAlerts.due(c.config.RegularStatusAlerts.AlertTimings, now, statusAlertWindow(c.config))
 
-		alertsArray = append(alertsArray, alertTime)
-	}
-
-	log.Printf("Current time : %v and alerts array : %v", currentTime, alertsArray)
+	log.Printf("Current time : %v and due alert timings : %v", now.Format(time.Kitchen), due)
 
 	var count float64 = 0
 
-	for _, statusAlertTime := range alertsArray {
-		if currentTime == statusAlertTime {
-			alreadySentAlert, _ := querier.AlertStatusCountFromPrometheus(c.config)
-			if alreadySentAlert == "false" {
-				telegramErr := alerter.SendTelegramAlert(msg, c.config)
-				emailErr := alerter.SendEmailAlert(msg, c.config)
-				slackErr := alerter.SendSlackAlert(msg, c.config)
-				if telegramErr != nil {
-					log.Printf("Error while sending vallidator status alert to telegram: %v", telegramErr)
-				}
-				if emailErr != nil {
-					log.Printf("Error while sending validator status alert to email: %v", emailErr)
-				}
-				if slackErr != nil {
-					log.Printf("Error while sending validator status alert to slack: %v", slackErr)
-				}
-				ch <- prometheus.MustNewConstMetric(c.statusAlertCount, prometheus.GaugeValue,
-					count, "true")
-				count = count + 1
-			} else {
-				ch <- prometheus.MustNewConstMetric(c.statusAlertCount, prometheus.GaugeValue,
-					count, "false")
-				return
-			}
+	for range due {
+		// prometheus remembers a status alert sent before a restart, the alert is sent when it can't be queried
+		alreadySentAlert, _ := querier.AlertStatusCountFromPrometheus(c.config)
+		if alreadySentAlert == "true" {
+			ch <- prometheus.MustNewConstMetric(c.statusAlertCount, prometheus.GaugeValue,
+				count, "false")
+			return
 		}
+		err := alerter.SendAlert(alerter.EventValidatorStatus, msg, severity, c.config)
+		if err != nil {
+			log.Printf("Error while sending validator status alert: %v", err)
+		}
+		ch <- prometheus.MustNewConstMetric(c.statusAlertCount, prometheus.GaugeValue,
+			count, "true")
+		count = count + 1
 	}
 }
 
@@ -454,26 +991,100 @@ func (c *solanaCollector) AlertValidatorStatus(msg string, ch chan<- prometheus.
 // 7. IP address
 // 8. Total transaction count
 // 9. Get current block time and previous block time and difference of both.
+// 10. Time taken by the scrape
+// 11. Next leader slot of validator and its ETA, whether it is in the leader schedule of the next epoch
+// 12. Number of cluster nodes per software version
+// 13. Whether each alert channel is enabled
+// 14. Slots the last vote of validator is behind the current slot of the validator rpc, and the estimated time until
+// it becomes delinquent at the trend of it
+// 15. First available block of the validator rpc
+// 16. Whether the ports of the node are reachable if probing is enabled
+// 17. Which tpu addresses the node advertises and send alert when forwarding is degraded
+// 18. Number of scrapes by whether their rpc calls succeeded
+// 19. Send alert when the rate limit headroom of an rpc endpoint runs low
+// 20. Estimated time since the current epoch began
+// 21. Whether the version of the node meets the required version and send alert as the required epoch approaches
+// 22. Whether the epoch changed during the scrape
+// 23. Number of healthy rpc endpoints and send alert when too few of them are healthy
+// 24. Slots between the processed and finalized slot of network and send alert when it is abnormally high
+// 25. Number of activated features the version of the node likely lacks and send alert when there are any
+//
+// and logs a summary line of the scrape at its end.
 func (c *solanaCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, recorded := c.recordScrape(ch)
+	defer recorded()
+
+	start := time.Now()
+	// outcomes of the rpc calls of the scrape, to count it as success, partial or failure
+	var calls scrapeCalls
+
+	// Epoch info is shared by vote credits and leader schedule, export whether it is available
+	epochAvailable := float64(1)
+	epochInfo, err := c.getCachedEpochInfo()
+	calls.record(err)
+	if err != nil {
+		log.Printf("Error while getting epoch info : %v", err)
+		epochAvailable = 0
+	} else {
+		c.emitEpochAge(ch, epochInfo)
+	}
+	ch <- prometheus.MustNewConstMetric(c.epochInfoAvailable, prometheus.GaugeValue, epochAvailable)
+
 	// Only collect metrics that are NOT handled by WatchSlots()
 	// WatchSlots() already handles: balance, nodeHealth, epochInfo, skipRate, blockProduction
 
 	// Vote accounts - only needed for validator-specific metrics, not for general prometheus metrics
-	accs, err := monitor.GetVoteAccounts(c.config, utils.Validator)
-	if err != nil {
-		ch <- prometheus.NewInvalidMetric(c.totalValidatorsDesc, err)
-		ch <- prometheus.NewInvalidMetric(c.validatorActivatedStake, err)
-		ch <- prometheus.NewInvalidMetric(c.validatorLastVote, err)
-		ch <- prometheus.NewInvalidMetric(c.validatorRootSlot, err)
-		ch <- prometheus.NewInvalidMetric(c.validatorDelinquent, err)
-	} else {
-		c.mustEmitMetrics(ch, accs) // emit vote account metrics
+	var voteAccounts *types.GetVoteAccountsResponse
+	if !c.config.IsRPCNode() {
+		accs, err := monitor.GetVoteAccounts(c.config, c.config.RPCSource(utils.VoteAccountsGroup, utils.Validator))
+		calls.record(err)
+		if err != nil {
+			descs := []*prometheus.Desc{c.totalValidatorsDesc, c.validatorActivatedStake,
+				c.validatorLastVote, c.validatorRootSlot, c.validatorDelinquent}
+			if c.legacyActivatedStake != nil {
+				descs = append(descs, c.legacyActivatedStake)
+			}
+			c.emitError(ch, "vote_accounts", err, descs...)
+		} else {
+			accs = resolveOwnVoteAccount(accs, c.config.ValDetails.PubKey, c.config.ValDetails.VoteAccountPrecedence)
+			voteAccounts = &accs
+			status, _ := votingStatus(accs, c.config.ValDetails.PubKey)
+			c.lastVotingStatus.Store(status)
+			c.emitGroup(ch, "vote_accounts", func(ch chan<- prometheus.Metric) {
+				c.mustEmitMetrics(ch, accs) // emit vote account metrics
+			})
+		}
 	}
 
 	// get version - this is static, low frequency call
 	version, err := monitor.GetVersion(c.config)
+	calls.record(err)
 	if version.Result.SolanaCore != "" {
 		ch <- prometheus.MustNewConstMetric(c.solanaVersion, prometheus.GaugeValue, 1, version.Result.SolanaCore)
+		c.emitUpgradeReadiness(ch, version.Result.SolanaCore)
+		c.emitMissingFeatures(ch, version.Result.SolanaCore)
+	}
+
+	// get software versions of cluster nodes, cached as the list is large
+	c.emitClusterVersions(ch)
+	// probe the ports the node advertises in the cluster nodes if enabled
+	c.emitPortReachability(ch)
+	// tpu addresses the node advertises, transactions are sent and forwarded to them
+	c.emitTPUForwarding(ch)
+
+	// alert channels enabled in config
+	for channel, enabled := range map[string]bool{
+		"telegram": c.config.EnableAlerts.EnableTelegramAlerts,
+		"email":    c.config.EnableAlerts.EnableEmailAlerts,
+		"slack":    c.config.EnableAlerts.EnableSlackAlerts,
+	} {
+		var v float64
+		if enabled {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.alertChannelEnabled, prometheus.GaugeValue, v, channel)
 	}
 
 	// NOTE: Removed duplicate balance calls that WatchSlots() already handles:
@@ -493,27 +1104,112 @@ func (c *solanaCollector) Collect(ch chan<- prometheus.Metric) {
 
 	// get slot leader - keeping this as it's used by some dashboards
 	leader, err := monitor.GetSlotLeader(c.config)
+	calls.record(err)
 	if err != nil {
-		ch <- prometheus.NewInvalidMetric(c.slotLeader, err)
+		c.emitError(ch, "slot_leader", err, c.slotLeader)
 	} else {
-		if leader.Result != "" {
-			ch <- prometheus.MustNewConstMetric(c.slotLeader, prometheus.GaugeValue, 1, leader.Result)
-		}
+		c.emitGroup(ch, "slot_leader", func(ch chan<- prometheus.Metric) {
+			if leader.Result != "" {
+				ch <- prometheus.MustNewConstMetric(c.slotLeader, prometheus.GaugeValue, 1, leader.Result)
+			}
+		})
 	}
 
 	// get current validator slot - single call
-	slot, err := monitor.GetCurrentSlot(c.config, utils.Validator)
+	currentSlot := int64(-1)
+	slot, err := monitor.GetCurrentSlot(c.config, c.config.RPCSource(utils.CurrentSlotGroup, utils.Validator))
+	calls.record(err)
 	if err != nil {
 		log.Printf("Error while getting current slot info : %v", err)
 	} else {
+		currentSlot = slot.Result
 		cs := strconv.FormatInt(slot.Result, 10)
 		ch <- prometheus.MustNewConstMetric(c.currentSlot, prometheus.GaugeValue, float64(slot.Result), cs)
+
+		if !c.config.IsRPCNode() {
+			c.emitNextLeaderSlot(ch, slot.Result)
+			c.emitNextLeaderSchedule(ch)
+		}
+		if voteAccounts != nil {
+			c.emitLocalVoteLag(ch, slot.Result, *voteAccounts)
+		}
+		if epochInfo != nil {
+			c.emitEpochBoundary(ch, epochInfo, slot.Result)
+		}
+	}
+
+	// gap between processed and finalized slot of network, grows when the cluster stops finalizing
+	calls.record(c.emitFinalizationLag(ch))
+
+	// first available block of the ledger, cached as it only advances as the node prunes it
+	first, err := monitor.GetFirstAvailableBlock(c.config, utils.Validator)
+	calls.record(err)
+	if err != nil {
+		log.Printf("Error while getting first available block : %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.firstAvailableBlock, prometheus.GaugeValue, float64(first))
 	}
 
 	// tx count - keeping this but it could be moved to WatchSlots if needed
-	count, _ := monitor.GetTxCount(c.config)
-	txcount := utils.NearestThousandFormat(float64(count.Result))
-	ch <- prometheus.MustNewConstMetric(c.txCount, prometheus.GaugeValue, float64(count.Result), txcount)
+	count, err := monitor.GetTxCount(c.config)
+	calls.record(err)
+	var txcount []string
+	if c.config.Prometheus.TxCountLabel == "compact" {
+		txcount = append(txcount, utils.CompactFormat(float64(count.Result), c.config.Prometheus.TxCountLabelPrecision))
+	}
+	ch <- prometheus.MustNewConstMetric(c.txCount, prometheus.GaugeValue, float64(count.Result), txcount...)
+
+	ch <- prometheus.MustNewConstMetric(c.scrapeDuration, prometheus.GaugeValue, time.Since(start).Seconds())
+
+	c.scrapes[calls.result()]++
+	for result, n := range c.scrapes {
+		ch <- prometheus.MustNewConstMetric(c.scrapesTotal, prometheus.CounterValue, n, result)
+	}
+	log.Print(c.summarizeScrape(calls.result(), time.Since(start), voteAccounts, currentSlot))
+
+	monitor.AlertRateLimits(c.config)
+	monitor.AlertEndpointHealth(c.config)
+}
+
+// emitGroup forwards the metrics emitted by fn and remembers them as the last good values of the group
+func (c *solanaCollector) emitGroup(ch chan<- prometheus.Metric, group string, fn func(ch chan<- prometheus.Metric)) {
+	groupCh := make(chan prometheus.Metric)
+	done := make(chan struct{})
+
+	var metrics []prometheus.Metric
+	go func() {
+		for m := range groupCh {
+			metrics = append(metrics, m)
+			ch <- m
+		}
+		close(done)
+	}()
+
+	fn(groupCh)
+	close(groupCh)
+	<-done
+
+	c.lastGood[group] = metrics
+}
+
+// emitError handles a failed rpc call of a metric group. With on_error set to hold_last the last good
+// metrics of the group are exported again, otherwise the given descriptors are marked as invalid.
+func (c *solanaCollector) emitError(ch chan<- prometheus.Metric, group string, err error, descs ...*prometheus.Desc) {
+	if strings.EqualFold(c.config.Scraper.OnError, "hold_last") {
+		metrics, ok := c.lastGood[group]
+		if !ok {
+			log.Printf("No last good %s metrics to hold after error : %v", group, err)
+			return
+		}
+		for _, m := range metrics {
+			ch <- m
+		}
+		return
+	}
+
+	for _, desc := range descs {
+		ch <- prometheus.NewInvalidMetric(desc, err)
+	}
 }
 
 // getClusterNodeInfo returns gossip address of node
@@ -590,7 +1286,7 @@ func (c *solanaCollector) getCachedEpochInfo() (*types.EpochInfo, error) {
 		return c.cachedEpochInfo, nil
 	}
 
-	epochInfo, err := monitor.GetEpochInfo(c.config, utils.Validator)
+	epochInfo, err := monitor.GetEpochInfo(c.config, c.config.RPCSource(utils.EpochInfoGroup, utils.Validator))
 	if err != nil {
 		return nil, err
 	}
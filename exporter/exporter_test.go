@@ -0,0 +1,523 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+var fqNameRegexp = regexp.MustCompile(`fqName: "([^"]+)"`)
+
+// newTestRPCServer starts a json rpc server which answers every method with the given raw result
+func newTestRPCServer(t *testing.T, results map[string]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, ok := results[req.Method]
+		if !ok {
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%s,"id":1}`, result)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestConfig returns a config pointing both validator and network rpc at the given endpoint
+func newTestConfig(endpoint string) *config.Config {
+	return &config.Config{
+		Endpoints: config.Endpoints{
+			RPCEndpoint: endpoint,
+			NetworkRPC:  endpoint,
+		},
+		ValDetails: config.ValDetails{
+			ValidatorName: "test-val",
+			PubKey:        "valPubKey",
+			VoteKey:       "valVoteKey",
+		},
+	}
+}
+
+// collectMetrics runs one collection and returns the written metrics grouped by metric name
+func collectMetrics(t *testing.T, c prometheus.Collector) map[string][]*dto.Metric {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	metrics := make(map[string][]*dto.Metric)
+	for m := range ch {
+		match := fqNameRegexp.FindStringSubmatch(m.Desc().String())
+		if match == nil {
+			t.Fatalf("unexpected metric descriptor %s", m.Desc())
+		}
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			metrics[match[1]] = append(metrics[match[1]], nil)
+			continue
+		}
+		metrics[match[1]] = append(metrics[match[1]], &pb)
+	}
+	return metrics
+}
+
+const testVoteAccounts = `{
+	"current": [
+		{"activatedStake": 5000000000000, "commission": 10, "epochCredits": [[100, 4000, 3000]], "epochVoteAccount": true, "lastVote": 1000, "nodePubkey": "valPubKey", "rootSlot": 968, "votePubkey": "valVoteKey"},
+		{"activatedStake": 1000000000000, "commission": 5, "epochCredits": [[100, 5000, 4000]], "epochVoteAccount": true, "lastVote": 1002, "nodePubkey": "otherPubKey", "rootSlot": 970, "votePubkey": "otherVoteKey"}
+	],
+	"delinquent": []
+}`
+
+// testRPCResults are the canned responses used by most collector tests
+func testRPCResults() map[string]string {
+	return map[string]string{
+		"getVoteAccounts":     testVoteAccounts,
+		"getEpochInfo":        `{"absoluteSlot": 1010, "blockHeight": 900, "epoch": 100, "slotIndex": 10, "slotsInEpoch": 432000}`,
+		"getVersion":          `{"solana-core": "1.14.17"}`,
+		"getSlotLeader":       `"otherPubKey"`,
+		"getSlot":             `1010`,
+		"getTransactionCount": `123456`,
+	}
+}
+
+func TestCollectRPCNodeType(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(srv.URL)
+	cfg.ValDetails.NodeType = "rpc"
+
+	metrics := collectMetrics(t, NewSolanaCollector(cfg))
+
+	for _, name := range []string{
+		"solana_active_validators",
+		"solana_validator_activated_stake_sol",
+		"solana_validator_last_vote",
+		"solana_val_commission",
+		"solana_validator_vote_credits",
+		"solana_network_vote_credits",
+	} {
+		if _, ok := metrics[name]; ok {
+			t.Errorf("Expected %s to be absent for rpc node, but it was exported", name)
+		}
+	}
+	for _, name := range []string{"solana_node_version", "solana_current_slot", "solana_tx_count"} {
+		if _, ok := metrics[name]; !ok {
+			t.Errorf("Expected %s to be exported for rpc node", name)
+		}
+	}
+}
+
+func TestCollectValidatorNodeType(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	metrics := collectMetrics(t, NewSolanaCollector(newTestConfig(srv.URL)))
+
+	for _, name := range []string{"solana_validator_activated_stake_sol", "solana_val_commission", "solana_node_version"} {
+		if _, ok := metrics[name]; !ok {
+			t.Errorf("Expected %s to be exported for validator node", name)
+		}
+	}
+}
+
+func TestCollectScrapeDuration(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	metrics := collectMetrics(t, NewSolanaCollector(newTestConfig(srv.URL)))
+
+	durations := metrics["solana_scrape_duration_seconds"]
+	if len(durations) != 1 {
+		t.Fatalf("Expected one scrape duration metric, got %d", len(durations))
+	}
+	if durations[0].GetGauge().GetValue() <= 0 {
+		t.Error("Expected positive scrape duration, got ", durations[0].GetGauge().GetValue())
+	}
+}
+
+func TestCollectOnError(t *testing.T) {
+	for _, tc := range []struct {
+		onError     string
+		expectValid bool
+	}{
+		{onError: "invalidate", expectValid: false},
+		{onError: "hold_last", expectValid: true},
+	} {
+		srv := newTestRPCServer(t, testRPCResults())
+
+		cfg := newTestConfig(srv.URL)
+		cfg.Scraper.OnError = tc.onError
+		c := NewSolanaCollector(cfg)
+
+		collectMetrics(t, c) // first scrape succeeds and caches the last good values
+
+		srv.Close()
+		metrics := collectMetrics(t, c)
+
+		stake := metrics["solana_validator_activated_stake_sol"]
+		if len(stake) != 1 {
+			t.Fatalf("%s: expected one activated stake metric, got %d", tc.onError, len(stake))
+		}
+		if tc.expectValid {
+			if stake[0] == nil || stake[0].GetGauge().GetValue() != 5000 {
+				t.Errorf("%s: expected last good activated stake 5000, got %v", tc.onError, stake[0])
+			}
+		} else if stake[0] != nil {
+			t.Errorf("%s: expected invalid activated stake metric, got %v", tc.onError, stake[0])
+		}
+	}
+}
+
+func TestCollectEpochInfoUnavailable(t *testing.T) {
+	results := testRPCResults()
+	results["getEpochInfo"] = `"unavailable"` // fails to unmarshal into epoch info
+	srv := newTestRPCServer(t, results)
+
+	metrics := collectMetrics(t, NewSolanaCollector(newTestConfig(srv.URL)))
+
+	available := metrics["solana_epoch_info_available"]
+	if len(available) != 1 || available[0].GetGauge().GetValue() != 0 {
+		t.Errorf("Expected epoch info to be unavailable, got %v", available)
+	}
+	if _, ok := metrics["solana_validator_vote_credits"]; ok {
+		t.Error("Expected vote credits to be skipped without epoch info")
+	}
+	if _, ok := metrics["solana_validator_activated_stake_sol"]; !ok {
+		t.Error("Expected other vote account metrics to still be exported")
+	}
+}
+
+func TestCollectZeroActivatedStake(t *testing.T) {
+	var texts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err == nil {
+			texts = append(texts, payload["text"])
+		}
+	}))
+	t.Cleanup(slack.Close)
+
+	results := testRPCResults()
+	results["getVoteAccounts"] = `{
+		"current": [
+			{"activatedStake": 0, "commission": 10, "epochCredits": [[100, 4000, 3000]], "epochVoteAccount": true, "lastVote": 1000, "nodePubkey": "valPubKey", "rootSlot": 968, "votePubkey": "valVoteKey"}
+		],
+		"delinquent": []
+	}`
+	srv := newTestRPCServer(t, results)
+
+	cfg := newTestConfig(srv.URL)
+	cfg.EnableAlerts.EnableSlackAlerts = true
+	cfg.Slack.WebhookURL = slack.URL
+	cfg.AlerterPreferences.ActiveSetAlerts = "yes"
+	c := NewSolanaCollector(cfg)
+
+	metrics := collectMetrics(t, c)
+	collectMetrics(t, c) // alert is sent only once while the validator stays out of the active set
+
+	active := metrics["solana_validator_active"]
+	if len(active) != 1 || active[0].GetGauge().GetValue() != 0 {
+		t.Errorf("Expected validator to be inactive, got %v", active)
+	}
+
+	var activeSetAlerts int
+	for _, text := range texts {
+		if strings.Contains(text, "active set") {
+			activeSetAlerts++
+		}
+		if strings.Contains(text, "NOT VOTING") {
+			t.Error("Expected no NOT VOTING alert for an epoch vote account, got: ", text)
+		}
+	}
+	if activeSetAlerts != 1 {
+		t.Errorf("Expected one active set alert, got %d in %v", activeSetAlerts, texts)
+	}
+}
+
+// registerAlerterMetrics registers the alerter metrics with the default registry once for alertsSent
+var registerAlerterMetrics sync.Once
+
+// alertsSent returns the number of alerts sent for the given event and severity from the default registry
+func alertsSent(t *testing.T, event, severity string) float64 {
+	registerAlerterMetrics.Do(func() { alerter.RegisterMetrics(prometheus.DefaultRegisterer) })
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal("Error while gathering metrics :", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "solana_alerts_sent_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["event"] == event && labels["severity"] == severity {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+const testDelinquentVoteAccounts = `{
+	"current": [],
+	"delinquent": [
+		{"activatedStake": 5000000000000, "commission": 10, "epochCredits": [[100, 4000, 3000]], "epochVoteAccount": true, "lastVote": 900, "nodePubkey": "valPubKey", "rootSlot": 868, "votePubkey": "valVoteKey"}
+	]
+}`
+
+func TestCollectDelinquentAlertsSent(t *testing.T) {
+	results := testRPCResults()
+	results["getVoteAccounts"] = testDelinquentVoteAccounts
+	srv := newTestRPCServer(t, results)
+
+	before := alertsSent(t, "delinquent", "warning")
+	collectMetrics(t, NewSolanaCollector(newTestConfig(srv.URL)))
+
+	if got := alertsSent(t, "delinquent", "warning") - before; got != 1 {
+		t.Errorf("Expected 1 delinquent alert to be counted, got %v", got)
+	}
+}
+
+func TestCollectDelinquentAlertLocale(t *testing.T) {
+	var texts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err == nil {
+			texts = append(texts, payload["text"])
+		}
+	}))
+	t.Cleanup(slack.Close)
+
+	results := testRPCResults()
+	results["getVoteAccounts"] = testDelinquentVoteAccounts
+	srv := newTestRPCServer(t, results)
+
+	cfg := newTestConfig(srv.URL)
+	cfg.EnableAlerts.EnableSlackAlerts = true
+	cfg.Slack.WebhookURL = slack.URL
+	cfg.AlertFormat.Locale = "de"
+	collectMetrics(t, NewSolanaCollector(cfg))
+
+	if len(texts) != 1 || !strings.Contains(texts[0], "Ihr Solana Validator ist seit 0s im Status DELINQUENT") {
+		t.Errorf("Expected the delinquent alert in german, got %v", texts)
+	}
+}
+
+func TestCollectStartupGracePeriod(t *testing.T) {
+	results := testRPCResults()
+	results["getVoteAccounts"] = testDelinquentVoteAccounts
+	srv := newTestRPCServer(t, results)
+
+	cfg := newTestConfig(srv.URL)
+	cfg.AlerterPreferences.StartupGracePeriod = "1h"
+
+	before := alertsSent(t, "delinquent", "warning")
+	metrics := collectMetrics(t, NewSolanaCollector(cfg))
+
+	if got := alertsSent(t, "delinquent", "warning") - before; got != 0 {
+		t.Errorf("Expected no delinquent alert within the grace period, got %v", got)
+	}
+	if delinquent := metrics["solana_validator_delinquent"]; len(delinquent) != 1 || delinquent[0].GetGauge().GetValue() != 1 {
+		t.Errorf("Expected delinquent metric to still be exported, got %v", delinquent)
+	}
+}
+
+func TestTrackDelinquency(t *testing.T) {
+	c := NewSolanaCollector(newTestConfig(""))
+	start := time.Now()
+
+	if d := c.trackDelinquency(true, start); d != 0 {
+		t.Errorf("Expected delinquency to start at 0s, got %v", d)
+	}
+	if d := c.trackDelinquency(true, start.Add(15*time.Minute)); d != 15*time.Minute || d < delinquentCriticalAfter {
+		t.Errorf("Expected continued delinquency of 15m to be critical, got %v", d)
+	}
+	if d := c.trackDelinquency(false, start.Add(16*time.Minute)); d != 0 {
+		t.Errorf("Expected delinquency to reset on recovery, got %v", d)
+	}
+	if d := c.trackDelinquency(true, start.Add(20*time.Minute)); d != 0 {
+		t.Errorf("Expected new delinquency to start at 0s, got %v", d)
+	}
+}
+
+// TestCollectParallel is meant to be run with the race detector, two scrapes share the collector caches
+func TestCollectParallel(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+
+	done := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go func() {
+			ch := make(chan prometheus.Metric)
+			go func() {
+				c.Collect(ch)
+				close(ch)
+			}()
+			for range ch {
+			}
+			done <- struct{}{}
+		}()
+	}
+	<-done
+	<-done
+}
+
+func TestCollectAlertChannelEnabled(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(srv.URL)
+	cfg.EnableAlerts.EnableTelegramAlerts = true
+	cfg.EnableAlerts.EnableSlackAlerts = true
+
+	enabled := make(map[string]float64)
+	for _, m := range collectMetrics(t, NewSolanaCollector(cfg))["solana_alert_channel_enabled"] {
+		enabled[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+	}
+
+	expected := map[string]float64{"telegram": 1, "email": 0, "slack": 1}
+	if !reflect.DeepEqual(enabled, expected) {
+		t.Errorf("Expected alert channels %v, got %v", expected, enabled)
+	}
+}
+
+func TestCollectRPCSources(t *testing.T) {
+	// vote accounts are only available from the network rpc
+	valResults := testRPCResults()
+	delete(valResults, "getVoteAccounts")
+	val := newTestRPCServer(t, valResults)
+	network := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(val.URL)
+	cfg.Endpoints.NetworkRPC = network.URL
+
+	// the rpc error of validator rpc marks the metric invalid
+	if m := collectMetrics(t, NewSolanaCollector(cfg))["solana_validator_activated_stake_sol"]; len(m) != 0 && m[0] != nil {
+		t.Fatal("Expected vote accounts to be fetched from validator rpc by default")
+	}
+
+	cfg.Endpoints.RPCSources = map[string]string{utils.VoteAccountsGroup: utils.Network}
+	if m := collectMetrics(t, NewSolanaCollector(cfg))["solana_validator_activated_stake_sol"]; len(m) == 0 || m[0] == nil {
+		t.Error("Expected vote accounts to be fetched from network rpc")
+	}
+}
+
+func TestCollectStaticLabels(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(srv.URL)
+	cfg.Prometheus.StaticLabels = map[string]string{"region": "eu", "datacenter": "fra1"}
+
+	for name, ms := range collectMetrics(t, NewSolanaCollector(cfg)) {
+		for _, m := range ms {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["region"] != "eu" || labels["datacenter"] != "fra1" {
+				t.Errorf("Expected static labels on %s, got %v", name, labels)
+			}
+		}
+	}
+}
+
+func TestCollectValidatorLabel(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(srv.URL)
+	cfg.Prometheus.ValidatorLabel = true
+
+	for name, ms := range collectMetrics(t, NewSolanaCollector(cfg)) {
+		for _, m := range ms {
+			found := false
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "validator" && l.GetValue() == "test-val" {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Expected validator label on %s, got %v", name, m.GetLabel())
+			}
+		}
+	}
+}
+
+func TestCollectTxCount(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	for _, tc := range []struct {
+		label     string
+		precision int
+		expected  map[string]string
+	}{
+		{"", 0, map[string]string{}},
+		{"compact", 1, map[string]string{"solana_tx_count": "123.5K"}},
+		{"compact", 0, map[string]string{"solana_tx_count": "123K"}},
+	} {
+		cfg := newTestConfig(srv.URL)
+		cfg.Prometheus.TxCountLabel = tc.label
+		cfg.Prometheus.TxCountLabelPrecision = tc.precision
+
+		ms := collectMetrics(t, NewSolanaCollector(cfg))["solana_tx_count"]
+		if len(ms) != 1 || ms[0].GetGauge().GetValue() != 123456 {
+			t.Fatalf("Expected raw tx count 123456 with label %q, got %v", tc.label, ms)
+		}
+		labels := make(map[string]string)
+		for _, l := range ms[0].GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if !reflect.DeepEqual(labels, tc.expected) {
+			t.Errorf("Expected labels %v with label %q and precision %d, got %v", tc.expected, tc.label, tc.precision, labels)
+		}
+	}
+}
+
+func TestCollectNetworkCreditsMinStake(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	for _, tc := range []struct {
+		minStake float64
+		current  float64
+	}{
+		{0, 4500},    // average of both vote accounts
+		{2000, 4000}, // only the vote account with 5000 SOL
+	} {
+		cfg := newTestConfig(srv.URL)
+		cfg.Prometheus.NetworkCreditsMinStake = tc.minStake
+
+		var current float64
+		for _, m := range collectMetrics(t, NewSolanaCollector(cfg))["solana_network_vote_credits"] {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "type" && l.GetValue() == "current" {
+					current = m.GetGauge().GetValue()
+				}
+			}
+		}
+		if current != tc.current {
+			t.Errorf("Expected network average credits %v with min stake %v, got %v", tc.current, tc.minStake, current)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// finalizationLag returns the number of slots the finalized slot is behind the processed slot, 0 if the slots were
+// fetched in the order that the finalized one is ahead
+func finalizationLag(processed, finalized int64) int64 {
+	if lag := processed - finalized; lag > 0 {
+		return lag
+	}
+	return 0
+}
+
+// emitFinalizationLag exports the slots between the processed and finalized slot of the network rpc and sends an
+// alert when it reaches the configured threshold. It is about 32 slots while the cluster finalizes normally and
+// grows for every node during a cluster wide finalization stall, unlike the lag of a single validator. The error of
+// fetching the slots is returned for the scrape result.
+func (c *solanaCollector) emitFinalizationLag(ch chan<- prometheus.Metric) error {
+	processed, err := monitor.GetProcessedSlot(c.config, utils.Network)
+	if err != nil {
+		log.Printf("Error while getting network processed slot : %v", err)
+		return err
+	}
+	finalized, err := monitor.GetFinalizedSlot(c.config, utils.Network)
+	if err != nil {
+		log.Printf("Error while getting network finalized slot : %v", err)
+		return err
+	}
+
+	lag := finalizationLag(processed.Result, finalized.Result)
+	ch <- prometheus.MustNewConstMetric(c.finalizationLag, prometheus.GaugeValue, float64(lag))
+
+	threshold := c.config.AlertingThresholds.FinalizationLagThreshold
+	if threshold <= 0 {
+		return nil
+	}
+	stalled := lag >= threshold
+	if stalled && !c.finalizationLagAlerted {
+		err := alerter.SendAlert(alerter.EventFinalizationLag, fmt.Sprintf("Finalization Lag Alert : The network finalized slot is %d slots behind the processed slot, reaching the configured threshold %d. The cluster may be stalling, not only your validator",
+			lag, threshold), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending finalization lag alert: %v", err)
+		}
+	}
+	c.finalizationLagAlerted = stalled
+	return nil
+}
@@ -0,0 +1,93 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestFinalizationLag(t *testing.T) {
+	if lag := finalizationLag(1042, 1010); lag != 32 {
+		t.Errorf("Expected finalization lag of 32 slots, got %d", lag)
+	}
+	// the finalized slot was fetched after the processed slot and overtook it
+	if lag := finalizationLag(1010, 1011); lag != 0 {
+		t.Errorf("Expected no finalization lag when finalized is ahead, got %d", lag)
+	}
+}
+
+func TestCollectFinalizationLag(t *testing.T) {
+	results := testRPCResults()
+	results["getFirstAvailableBlock"] = `43000000`
+	// getSlot answers by the commitment of the request, the other methods like newTestRPCServer
+	slots := map[string]int64{"processed": 1500, "finalized": 1300, "confirmed": 1490}
+	failed := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params []struct {
+				Commitment string `json:"commitment"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Method == "getSlot" && len(req.Params) > 0 {
+			if failed && req.Params[0].Commitment == "finalized" {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			if slot, ok := slots[req.Params[0].Commitment]; ok {
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%d,"id":1}`, slot)
+				return
+			}
+		}
+		result, ok := results[req.Method]
+		if !ok {
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%s,"id":1}`, result)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(srv.URL)
+	cfg.AlertingThresholds.FinalizationLagThreshold = 150
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, alerter.EventFinalizationLag, alerter.Warning)
+	for i := 0; i < 2; i++ {
+		ms := collectMetrics(t, c)["solana_finalization_lag_slots"]
+		if len(ms) != 1 || ms[0].GetGauge().GetValue() != 200 {
+			t.Fatalf("Expected a finalization lag of 200 slots, got %v", ms)
+		}
+	}
+	if got := alertsSent(t, alerter.EventFinalizationLag, alerter.Warning) - before; got != 1 {
+		t.Errorf("Expected 1 finalization lag alert, got %v", got)
+	}
+
+	// a failing finalized slot call doesn't count the scrape as successful
+	successes := func() float64 {
+		for _, m := range collectMetrics(t, c)["solana_scrapes_total"] {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "result" && l.GetValue() == scrapeSuccess {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+		return 0
+	}
+	before = successes()
+	if before == 0 {
+		t.Fatal("Expected successful scrapes before the finalized slot fails, got none")
+	}
+	failed = true
+	if got := successes(); got != before {
+		t.Errorf("Expected no successful scrape when the finalized slot can't be fetched, got %v more", got-before)
+	}
+}
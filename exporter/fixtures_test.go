@@ -0,0 +1,51 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+func TestCollectTestMode(t *testing.T) {
+	// endpoints are not resolvable, every rpc request must be answered from the fixtures
+	cfg := newTestConfig("http://rpc.invalid")
+	cfg.ValDetails.PubKey = "FixtureVa1idator1dentity11111111111111111111"
+	cfg.ValDetails.VoteKey = "FixtureVa1idatorVote111111111111111111111111"
+	cfg.TestMode = config.TestMode{Enabled: true, FixturesDir: "../fixtures/rpc"}
+
+	if err := monitor.ConfigureTestMode(cfg); err != nil {
+		t.Fatal("Error while configuring test mode :", err)
+	}
+	defer monitor.ConfigureProxy(&config.Config{})
+
+	metrics := collectMetrics(t, NewSolanaCollector(cfg))
+
+	for name, ms := range metrics {
+		for _, m := range ms {
+			if m == nil {
+				t.Errorf("Expected no errored %s metric in test mode", name)
+			}
+		}
+	}
+
+	for name, want := range map[string]float64{
+		"solana_validator_activated_stake_sol":    5000,
+		"solana_validator_active":                 1,
+		"solana_node_version":                     1,
+		"solana_tx_count":                         123456,
+		"solana_validator_estimated_apy":          38.878,
+		"solana_validator_activating_stake_sol":   250,
+		"solana_validator_deactivating_stake_sol": 100,
+		"solana_first_available_block":            43000000,
+	} {
+		got := metrics[name]
+		if len(got) != 1 {
+			t.Errorf("Expected 1 %s metric from fixtures, got %d", name, len(got))
+			continue
+		}
+		if v := got[0].GetGauge().GetValue(); v < want || v > want+0.001 {
+			t.Errorf("Expected %s %v from fixtures, got %v", name, want, v)
+		}
+	}
+}
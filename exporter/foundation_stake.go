@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// emitFoundationStake exports whether a stake account of a configured foundation authority delegates to
+// validator, nothing is exported without configured authorities
+func (c *solanaCollector) emitFoundationStake(ch chan<- prometheus.Metric) {
+	if len(c.config.ValDetails.FoundationStakeAuthorities) == 0 {
+		return
+	}
+	var value float64
+	if c.stakeActivation.foundationStake {
+		value = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.hasFoundationStake, prometheus.GaugeValue, value)
+}
+
+// hasFoundationStake reports whether any of the stake accounts whose stake or withdraw authority is one of the
+// given foundation authorities delegates to the given vote account in the given epoch. A delegation counts until
+// the end of its deactivation epoch, as the stake stays active until then.
+func hasFoundationStake(accounts types.StakeAccounts, voteKey string, epoch int64, authorities []string) bool {
+	foundation := make(map[string]bool, len(authorities))
+	for _, a := range authorities {
+		foundation[a] = true
+	}
+
+	for _, account := range accounts.Result {
+		info := account.Account.Data.Parsed.Info
+		if info.Stake == nil || info.Stake.Delegation.Voter != voteKey {
+			continue
+		}
+		if !foundation[info.Meta.Authorized.Staker] && !foundation[info.Meta.Authorized.Withdrawer] {
+			continue
+		}
+		// epochs of delegations which never deactivate are u64 max and don't fit an int64
+		deactivation, err := strconv.ParseInt(info.Stake.Delegation.DeactivationEpoch, 10, 64)
+		if err != nil {
+			deactivation = math.MaxInt64
+		}
+		if deactivation >= epoch {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package exporter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestHasFoundationStake(t *testing.T) {
+	data := `{"jsonrpc": "2.0", "result": [
+		{"pubkey": "self", "account": {"data": {"parsed": {"type": "delegated", "info": {"meta": {"authorized": {"staker": "selfStaker", "withdrawer": "selfStaker"}}, "stake": {"delegation": {"voter": "valVoteKey", "stake": "5000000000000", "activationEpoch": "80", "deactivationEpoch": "18446744073709551615"}}}}}}},
+		{"pubkey": "foundation", "account": {"data": {"parsed": {"type": "delegated", "info": {"meta": {"authorized": {"staker": "foundationStaker", "withdrawer": "foundationWithdrawer"}}, "stake": {"delegation": {"voter": "valVoteKey", "stake": "40000000000000", "activationEpoch": "90", "deactivationEpoch": "18446744073709551615"}}}}}}},
+		{"pubkey": "foundationOther", "account": {"data": {"parsed": {"type": "delegated", "info": {"meta": {"authorized": {"staker": "foundationStaker", "withdrawer": "foundationWithdrawer"}}, "stake": {"delegation": {"voter": "otherVoteKey", "stake": "40000000000000", "activationEpoch": "90", "deactivationEpoch": "18446744073709551615"}}}}}}}
+	], "id": 1}`
+
+	var accounts types.StakeAccounts
+	if err := json.Unmarshal([]byte(data), &accounts); err != nil {
+		t.Fatal("Error while parsing stake accounts :", err)
+	}
+
+	if !hasFoundationStake(accounts, "valVoteKey", 100, []string{"foundationStaker"}) {
+		t.Error("Expected foundation stake by staker authority")
+	}
+	if !hasFoundationStake(accounts, "valVoteKey", 100, []string{"foundationWithdrawer"}) {
+		t.Error("Expected foundation stake by withdraw authority")
+	}
+	if hasFoundationStake(accounts, "valVoteKey", 100, []string{"unknownAuthority"}) {
+		t.Error("Expected no foundation stake of unknown authority")
+	}
+	if hasFoundationStake(accounts, "thirdVoteKey", 100, []string{"foundationStaker"}) {
+		t.Error("Expected no foundation stake of vote account without delegation")
+	}
+
+	// foundation stake deactivated in an earlier epoch is gone
+	accounts.Result[1].Account.Data.Parsed.Info.Stake.Delegation.DeactivationEpoch = "99"
+	if hasFoundationStake(accounts, "valVoteKey", 100, []string{"foundationStaker"}) {
+		t.Error("Expected no foundation stake after deactivation")
+	}
+}
@@ -0,0 +1,88 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+const (
+	// identityConflictWindow is the number of recent scrapes in which backward jumps of the last vote are counted
+	identityConflictWindow = 10
+	// identityConflictJumps is the number of backward jumps of the last vote within the window after which two
+	// nodes are suspected to vote with the identity, a single one may be a lagging rpc node
+	identityConflictJumps = 2
+)
+
+// identityConflict tracks the last votes of the vote accounts of validator to detect two nodes running the same identity
+type identityConflict struct {
+	lastVotes map[string]int64
+	jumps     []bool
+}
+
+// track records the last votes of the given vote accounts of the identity and reports whether the identity
+// is suspected to run on more than one node, i.e. it votes on more than one vote account or its last vote
+// jumped backwards repeatedly within the recent scrapes
+func (ic *identityConflict) track(accounts []types.VoteAccount) bool {
+	if ic.lastVotes == nil {
+		ic.lastVotes = make(map[string]int64)
+	}
+
+	var jumped bool
+	for _, account := range accounts {
+		if prev, ok := ic.lastVotes[account.VotePubkey]; ok && int64(account.LastVote) < prev {
+			jumped = true
+		}
+		ic.lastVotes[account.VotePubkey] = int64(account.LastVote)
+	}
+	ic.jumps = append(ic.jumps, jumped)
+	if len(ic.jumps) > identityConflictWindow {
+		ic.jumps = ic.jumps[len(ic.jumps)-identityConflictWindow:]
+	}
+
+	var jumps int
+	for _, j := range ic.jumps {
+		if j {
+			jumps++
+		}
+	}
+	return len(accounts) > 1 || jumps >= identityConflictJumps
+}
+
+// emitIdentityConflict exports whether the identity of validator is suspected to run on more than one node
+// and sends an alert when it is
+func (c *solanaCollector) emitIdentityConflict(ch chan<- prometheus.Metric, response types.GetVoteAccountsResponse) {
+	var accounts []types.VoteAccount
+	for _, account := range append(response.Result.Current, response.Result.Delinquent...) {
+		if account.NodePubkey == c.config.ValDetails.PubKey {
+			accounts = append(accounts, account)
+		}
+	}
+
+	conflict := c.identityConflict.track(accounts)
+
+	var value float64
+	if conflict {
+		value = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.identityConflictDesc, prometheus.GaugeValue, value)
+
+	if conflict && !c.identityConflictAlerted {
+		msg := "Identity Conflict Alert : The last vote of your validator jumped backwards repeatedly, the identity may be running on two nodes"
+		if len(accounts) > 1 {
+			msg = fmt.Sprintf("Identity Conflict Alert : The identity of your validator votes on %d vote accounts, it may be running on two nodes", len(accounts))
+		}
+		err := alerter.SendAlert(alerter.EventIdentityConflict, msg, alerter.Critical, c.config)
+		if err != nil {
+			log.Printf("Error while sending identity conflict alert: %v", err)
+		}
+	}
+	c.identityConflictAlerted = conflict
+	if err := alerter.Escalate(alerter.EventIdentityConflict, "Identity Conflict Alert : The identity of your validator may still be running on two nodes", conflict, c.config); err != nil {
+		log.Printf("Error while escalating identity conflict alert: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestIdentityConflict(t *testing.T) {
+	var ic identityConflict
+
+	// monotonic votes, then the last vote jumps back and forth as two nodes vote
+	for i, tc := range []struct {
+		lastVote int
+		conflict bool
+	}{
+		{1000, false},
+		{1010, false},
+		{1020, false},
+		{1015, false}, // a single jump may be a lagging rpc node
+		{1030, false},
+		{1022, true},
+		{1040, true},
+	} {
+		got := ic.track([]types.VoteAccount{{VotePubkey: "valVoteKey", LastVote: tc.lastVote}})
+		if got != tc.conflict {
+			t.Errorf("Scrape %d: expected conflict %v at last vote %d, got %v", i, tc.conflict, tc.lastVote, got)
+		}
+	}
+
+	// the jumps fall out of the window after enough monotonic scrapes
+	var got bool
+	for i := 0; i < identityConflictWindow; i++ {
+		got = ic.track([]types.VoteAccount{{VotePubkey: "valVoteKey", LastVote: 1050 + i}})
+	}
+	if got {
+		t.Error("Expected no conflict after monotonic votes")
+	}
+
+	if !ic.track([]types.VoteAccount{{VotePubkey: "valVoteKey", LastVote: 1100}, {VotePubkey: "otherVoteKey", LastVote: 1100}}) {
+		t.Error("Expected conflict when identity votes on two vote accounts")
+	}
+}
@@ -0,0 +1,65 @@
+package exporter
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+var variableLabelsRegexp = regexp.MustCompile(`variableLabels: \[([^\]]*)\]`)
+
+// descRegisterer keeps the descriptors of the registered collectors
+type descRegisterer struct {
+	descs []*prometheus.Desc
+}
+
+func (r *descRegisterer) Register(c prometheus.Collector) error {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		c.Describe(ch)
+		close(ch)
+	}()
+	for d := range ch {
+		r.descs = append(r.descs, d)
+	}
+	return nil
+}
+
+func (r *descRegisterer) MustRegister(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		r.Register(c)
+	}
+}
+
+func (r *descRegisterer) Unregister(prometheus.Collector) bool {
+	return false
+}
+
+func TestStaticLabelsClashingWithMetricLabels(t *testing.T) {
+	cfg := &config.Config{}
+	r := &descRegisterer{}
+	RegisterMetrics(r)
+	RegisterLegacyMetrics(r)
+	alerter.RegisterMetrics(r)
+	monitor.RegisterMetrics(r)
+	r.MustRegister(alerter.NewSuppressionsCollector(cfg), NewSolanaCollector(cfg))
+
+	for _, desc := range r.descs {
+		match := variableLabelsRegexp.FindStringSubmatch(desc.String())
+		if match == nil {
+			t.Fatalf("unexpected metric descriptor %s", desc)
+		}
+		for _, name := range strings.Fields(match[1]) {
+			cfg := &config.Config{Prometheus: config.Prometheus{StaticLabels: map[string]string{name: "x"}}}
+			if err := cfg.Validate(); err == nil {
+				t.Errorf("Expected validation error for static label %q set by %s", name, desc)
+			}
+		}
+	}
+}
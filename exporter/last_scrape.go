@@ -0,0 +1,77 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// recordScrape returns a channel which forwards the metrics of a scrape to ch, and a function to call at the end
+// of the scrape which keeps them as the last scrape
+func (c *solanaCollector) recordScrape(ch chan<- prometheus.Metric) (chan<- prometheus.Metric, func()) {
+	scrapeCh := make(chan prometheus.Metric)
+	done := make(chan struct{})
+
+	var metrics []prometheus.Metric
+	go func() {
+		for m := range scrapeCh {
+			metrics = append(metrics, m)
+			ch <- m
+		}
+		close(done)
+	}()
+
+	return scrapeCh, func() {
+		close(scrapeCh)
+		<-done
+
+		c.lastScrapeMu.Lock()
+		c.lastScrape, c.lastScrapedAt = metrics, time.Now()
+		c.lastScrapeMu.Unlock()
+	}
+}
+
+// lastScrapeCollector exports the metrics of the last scrape of the solana collector again
+type lastScrapeCollector struct {
+	c *solanaCollector
+	// mu makes concurrent collections wait for a single scrape when the last one is outdated
+	mu sync.Mutex
+}
+
+// LastScrape returns a collector of the metrics of the last scrape, for the status page, statsd and the metrics
+// file to reuse the values computed for prometheus instead of running the rpc calls and alerts of another scrape.
+// It only scrapes itself when there was no scrape within the scrape rate, e.g. when prometheus isn't set up.
+func (c *solanaCollector) LastScrape() prometheus.Collector {
+	return &lastScrapeCollector{c: c}
+}
+
+// Describe describes the metrics of the solana collector
+func (l *lastScrapeCollector) Describe(ch chan<- *prometheus.Desc) {
+	l.c.Describe(ch)
+}
+
+// Collect writes the metrics of the last scrape, scraping first if it is outdated
+func (l *lastScrapeCollector) Collect(ch chan<- prometheus.Metric) {
+	l.mu.Lock()
+	l.c.lastScrapeMu.Lock()
+	metrics, scrapedAt := l.c.lastScrape, l.c.lastScrapedAt
+	l.c.lastScrapeMu.Unlock()
+
+	if metrics == nil || time.Since(scrapedAt) >= scrapeRate(l.c.config) {
+		scrapeCh := make(chan prometheus.Metric)
+		go func() {
+			l.c.Collect(scrapeCh)
+			close(scrapeCh)
+		}()
+		metrics = nil
+		for m := range scrapeCh {
+			metrics = append(metrics, m)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, m := range metrics {
+		ch <- m
+	}
+}
@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastScrape(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+	last := c.LastScrape()
+
+	scrapes := func() float64 {
+		var n float64
+		for _, m := range collectMetrics(t, last)["solana_scrapes_total"] {
+			n += m.GetCounter().GetValue()
+		}
+		return n
+	}
+
+	// without a scrape yet the last scrape collector scrapes itself
+	if n := scrapes(); n != 1 {
+		t.Fatalf("Expected 1 scrape, got %v", n)
+	}
+
+	// the last scrape is exported again without scraping
+	if n := scrapes(); n != 1 {
+		t.Errorf("Expected the last scrape to be reused, got %v scrapes", n)
+	}
+	collectMetrics(t, c)
+	if n := scrapes(); n != 2 {
+		t.Errorf("Expected the scrape of the collector to be reused, got %v scrapes", n)
+	}
+
+	// an outdated scrape is replaced
+	c.lastScrapeMu.Lock()
+	c.lastScrapedAt = time.Now().Add(-time.Hour)
+	c.lastScrapeMu.Unlock()
+	if n := scrapes(); n != 3 {
+		t.Errorf("Expected an outdated scrape to be replaced, got %v scrapes", n)
+	}
+}
@@ -0,0 +1,79 @@
+package exporter
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+// slotDuration is the average time taken by a slot, used to estimate the time until a future slot
+const slotDuration = 400 * time.Millisecond
+
+// emitNextLeaderSlot exports the next leader slot of validator and the estimated time until it.
+// Nothing is exported when validator has no more leader slots in the current epoch.
+func (c *solanaCollector) emitNextLeaderSlot(ch chan<- prometheus.Metric, currentSlot int64) {
+	schedule, err := c.getLeaderSchedule()
+	if err != nil {
+		log.Printf("Error while getting leader schedule : %v", err)
+		return
+	}
+
+	next, ok := nextLeaderSlot(schedule, currentSlot)
+	if !ok {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.nextLeaderSlot, prometheus.GaugeValue, float64(next))
+	ch <- prometheus.MustNewConstMetric(c.nextLeaderSlotETA, prometheus.GaugeValue, leaderSlotETA(next, currentSlot).Seconds())
+}
+
+// getLeaderSchedule returns the sorted absolute leader slots of validator in the current epoch,
+// the schedule is fetched once per epoch
+func (c *solanaCollector) getLeaderSchedule() ([]int64, error) {
+	epochInfo, err := c.getCachedEpochInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.leaderSchedule != nil && c.leaderScheduleEpoch == epochInfo.Result.Epoch {
+		return c.leaderSchedule, nil
+	}
+
+	firstSlot := epochInfo.Result.AbsoluteSlot - epochInfo.Result.SlotIndex
+	slots, err := monitor.GetLeaderSlots(firstSlot, c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.leaderSchedule = absoluteLeaderSlots(slots, firstSlot)
+	c.leaderScheduleEpoch = epochInfo.Result.Epoch
+	return c.leaderSchedule, nil
+}
+
+// absoluteLeaderSlots converts the epoch relative slot indexes of leader schedule to sorted absolute slots
+func absoluteLeaderSlots(slots map[int64]string, firstSlot int64) []int64 {
+	schedule := make([]int64, 0, len(slots))
+	for index := range slots {
+		schedule = append(schedule, firstSlot+index)
+	}
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i] < schedule[j] })
+	return schedule
+}
+
+// nextLeaderSlot returns the first slot of sorted schedule at or after the current slot
+func nextLeaderSlot(schedule []int64, currentSlot int64) (int64, bool) {
+	i := sort.Search(len(schedule), func(i int) bool { return schedule[i] >= currentSlot })
+	if i == len(schedule) {
+		return 0, false
+	}
+	return schedule[i], true
+}
+
+// leaderSlotETA estimates the time until the given leader slot from the current slot
+func leaderSlotETA(next, currentSlot int64) time.Duration {
+	return time.Duration(next-currentSlot) * slotDuration
+}
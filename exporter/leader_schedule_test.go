@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextLeaderSlot(t *testing.T) {
+	// leader schedule indexes are relative to the first slot of the epoch
+	schedule := absoluteLeaderSlots(map[int64]string{
+		8:  "valPubKey",
+		0:  "valPubKey",
+		40: "valPubKey",
+		41: "valPubKey",
+	}, 1000)
+
+	next, ok := nextLeaderSlot(schedule, 1010)
+	if !ok || next != 1040 {
+		t.Fatalf("Expected next leader slot 1040, got %d (%v)", next, ok)
+	}
+	if eta := leaderSlotETA(next, 1010); eta != 12*time.Second {
+		t.Errorf("Expected ETA of 12s, got %v", eta)
+	}
+
+	if next, ok := nextLeaderSlot(schedule, 1008); !ok || next != 1008 {
+		t.Errorf("Expected current slot 1008 to be the next leader slot, got %d (%v)", next, ok)
+	}
+	if _, ok := nextLeaderSlot(schedule, 1042); ok {
+		t.Error("Expected no next leader slot after the last one in epoch")
+	}
+}
@@ -0,0 +1,35 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// legacyDesc returns the descriptor of a metric under the name it had before units were added to it,
+// nil unless legacy metric names are enabled in config
+func legacyDesc(cfg *config.Config, name, help string, variableLabels []string, labels prometheus.Labels) *prometheus.Desc {
+	if !cfg.Prometheus.LegacyMetricNames {
+		return nil
+	}
+	return prometheus.NewDesc(name, help+", deprecated", variableLabels, labels)
+}
+
+// RegisterLegacyMetrics registers the metrics updated by WatchSlots which were renamed under their
+// old names as well, so dashboards and alerts can be migrated
+func RegisterLegacyMetrics(r prometheus.Registerer) {
+	r.MustRegister(legacyGauge("account_balance", "Balance of validator identity account in SOL, deprecated", balance))
+	r.MustRegister(legacyGauge("account_balance_usd", "Balance of validator identity account in USD, deprecated", balanceUSD))
+}
+
+// legacyGauge returns a gauge of the given name which reports the current value of g
+func legacyGauge(name, help string, g prometheus.Gauge) prometheus.GaugeFunc {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 {
+		var m dto.Metric
+		if err := g.Write(&m); err != nil {
+			return 0
+		}
+		return m.GetGauge().GetValue()
+	})
+}
@@ -0,0 +1,78 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetricNamesAndHelp(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		c.Describe(ch)
+		close(ch)
+	}()
+	descs := make(map[string]string)
+	for d := range ch {
+		descs[fqNameRegexp.FindStringSubmatch(d.String())[1]] = d.String()
+	}
+
+	for name, help := range map[string]string{
+		"solana_validator_activated_stake_sol": "Activated stake of validator in SOL",
+		"solana_account_balance":               "Unused, see solana_account_balance_sol",
+		"solana_val_commission":                "Commission of validator in percent",
+	} {
+		if !strings.Contains(descs[name], help) {
+			t.Errorf("Expected %s with help %q, got %q", name, help, descs[name])
+		}
+	}
+	if _, ok := descs["solana_validator_activated_stake"]; ok {
+		t.Error("Expected no legacy metric names by default")
+	}
+
+	for _, tc := range []struct {
+		gauge prometheus.Gauge
+		name  string
+	}{
+		{balance, "solana_account_balance_sol"},
+		{balanceUSD, "solana_account_balance_usd"},
+	} {
+		if d := tc.gauge.Desc().String(); !strings.Contains(d, `fqName: "`+tc.name+`"`) {
+			t.Errorf("Expected gauge %s, got %s", tc.name, d)
+		}
+	}
+}
+
+func TestLegacyMetricNames(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(srv.URL)
+	cfg.Prometheus.LegacyMetricNames = true
+	metrics := collectMetrics(t, NewSolanaCollector(cfg))
+
+	for _, name := range []string{"solana_validator_activated_stake_sol", "solana_validator_activated_stake"} {
+		if ms := metrics[name]; len(ms) != 1 || ms[0].GetGauge().GetValue() != 5000 {
+			t.Errorf("Expected %s of 5000 SOL, got %v", name, ms)
+		}
+	}
+
+	balance.Set(12.5)
+	r := prometheus.NewRegistry()
+	RegisterLegacyMetrics(r)
+	mfs, err := r.Gather()
+	if err != nil {
+		t.Fatal("Error while gathering legacy metrics :", err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() == "account_balance" && mf.GetMetric()[0].GetGauge().GetValue() != 12.5 {
+			t.Errorf("Expected legacy account_balance to mirror solana_account_balance_sol, got %v", mf.GetMetric()[0])
+		}
+	}
+	if len(mfs) != 2 {
+		t.Errorf("Expected 2 legacy gauges, got %d", len(mfs))
+	}
+}
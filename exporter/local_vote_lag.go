@@ -0,0 +1,30 @@
+package exporter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// emitLocalVoteLag exports the slots the last vote of validator is behind the current slot of the validator rpc.
+// Compared with the lag of the validator rpc to the network, it tells a voting problem from a lagging rpc.
+// The trend of the lag forecasts when the validator becomes delinquent.
+func (c *solanaCollector) emitLocalVoteLag(ch chan<- prometheus.Metric, slot int64, response types.GetVoteAccountsResponse) {
+	if lag, ok := localVoteLag(slot, response, c.config.ValDetails.PubKey); ok {
+		ch <- prometheus.MustNewConstMetric(c.localVoteLag, prometheus.GaugeValue, float64(lag))
+		c.emitDelinquencyForecast(ch, lag, time.Now())
+	}
+}
+
+// localVoteLag returns the given current slot minus the last vote of the vote account of the given identity,
+// ok is false if the identity has no vote account
+func localVoteLag(slot int64, response types.GetVoteAccountsResponse, pubKey string) (lag int64, ok bool) {
+	for _, account := range append(response.Result.Current, response.Result.Delinquent...) {
+		if account.NodePubkey == pubKey {
+			return slot - int64(account.LastVote), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,39 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestLocalVoteLag(t *testing.T) {
+	var response types.GetVoteAccountsResponse
+	response.Result.Current = []types.VoteAccount{
+		{NodePubkey: "otherPubKey", LastVote: 1990},
+		{NodePubkey: "valPubKey", LastVote: 1850},
+	}
+
+	if lag, ok := localVoteLag(2000, response, "valPubKey"); !ok || lag != 150 {
+		t.Errorf("Expected local vote lag 150, got %d (ok %v)", lag, ok)
+	}
+	if _, ok := localVoteLag(2000, response, "unknownPubKey"); ok {
+		t.Error("Expected no local vote lag without vote account")
+	}
+
+	// delinquent validators are behind too
+	response.Result.Current, response.Result.Delinquent = response.Result.Current[:1], response.Result.Current[1:]
+	if lag, ok := localVoteLag(2000, response, "valPubKey"); !ok || lag != 150 {
+		t.Errorf("Expected local vote lag 150 of delinquent validator, got %d (ok %v)", lag, ok)
+	}
+}
+
+func TestCollectLocalVoteLag(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+
+	// current slot 1010 and last vote 1000
+	m := collectMetrics(t, c)["solana_validator_local_vote_lag"]
+	if len(m) != 1 || m[0].GetGauge().GetValue() != 10 {
+		t.Errorf("Expected local vote lag 10, got %v", m)
+	}
+}
@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// defaultScrapeRate is the interval at which metrics are written to the metrics file or statsd if not configured
+const defaultScrapeRate = 30 * time.Second
+
+// scrapeRate returns the configured scraper rate, defaultScrapeRate if not configured or invalid
+func scrapeRate(cfg *config.Config) time.Duration {
+	if cfg.Scraper.Rate == "" {
+		return defaultScrapeRate
+	}
+	d, err := time.ParseDuration(cfg.Scraper.Rate)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid scraper rate %q, using default %s", cfg.Scraper.Rate, defaultScrapeRate)
+		return defaultScrapeRate
+	}
+	return d
+}
+
+// WatchMetricsFile writes the metrics gathered from the given gatherer to the configured metrics file on every scrape
+func WatchMetricsFile(cfg *config.Config, g prometheus.Gatherer) {
+	rate := scrapeRate(cfg)
+	for {
+		if err := WriteMetricsFile(cfg.Prometheus.MetricsFilePath, g); err != nil {
+			log.Printf("Error while writing metrics file : %v", err)
+		}
+		time.Sleep(rate)
+	}
+}
+
+// WriteMetricsFile gathers the current metrics and writes them to the given path in the text exposition format.
+// The metrics are written to a temporary file which is then renamed, so readers never see a partial file.
+func WriteMetricsFile(path string, g prometheus.Gatherer) error {
+	return prometheus.WriteToTextfile(path, g)
+}
@@ -0,0 +1,39 @@
+package exporter
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWriteMetricsFile(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewSolanaCollector(newTestConfig(srv.URL)))
+
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	if err := WriteMetricsFile(path, reg); err != nil {
+		t.Fatal("Error while writing metrics file :", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range []string{
+		"solana_node_version",
+		"solana_current_slot",
+		"solana_tx_count",
+		"solana_validator_activated_stake_sol",
+		"solana_val_commission",
+		"solana_scrape_duration_seconds",
+	} {
+		if !strings.Contains(string(data), "# TYPE "+family+" ") {
+			t.Errorf("Expected metric family %s in metrics file", family)
+		}
+	}
+}
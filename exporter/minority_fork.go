@@ -0,0 +1,66 @@
+package exporter
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+const (
+	// minorityForkRootLag is the number of slots the root slot of validator may lag behind the
+	// finalized slot of network before it is considered diverged
+	minorityForkRootLag = 128
+	// minorityForkScrapes is the number of consecutive diverged scrapes after which validator is
+	// reported to be on a minority fork
+	minorityForkScrapes = 3
+)
+
+// emitMinorityFork exports whether the validator is voting on a minority fork and sends an alert when
+// it switches to one. A minority fork can't root slots as it lacks a supermajority of stake, so the votes
+// of validator keep advancing while its root slot falls behind the finalized slot of network.
+func (c *solanaCollector) emitMinorityFork(ch chan<- prometheus.Metric, account types.VoteAccount) {
+	finalized, err := monitor.GetFinalizedSlot(c.config, utils.Network)
+	if err != nil {
+		log.Printf("Error while getting network finalized slot : %v", err)
+		return
+	}
+
+	onFork := c.trackMinorityFork(int64(account.LastVote), int64(account.RootSlot), finalized.Result)
+
+	var value float64
+	if onFork {
+		value = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.onMinorityFork, prometheus.GaugeValue, value)
+
+	if onFork && !c.minorityForkAlerted {
+		err := alerter.SendAlert(alerter.EventMinorityFork, "Minority Fork Alert : Your validator keeps voting but its root slot is diverging from the network, it may be on a minority fork", alerter.Critical, c.config)
+		if err != nil {
+			log.Printf("Error while sending minority fork alert: %v", err)
+		}
+	}
+	c.minorityForkAlerted = onFork
+	if err := alerter.Escalate(alerter.EventMinorityFork, "Minority Fork Alert : Your validator is still on a minority fork", onFork, c.config); err != nil {
+		log.Printf("Error while escalating minority fork alert: %v", err)
+	}
+}
+
+// trackMinorityFork records the given last vote and root slot of validator along with the finalized slot of
+// network and reports whether the root slot has lagged behind while the validator kept voting for
+// minorityForkScrapes consecutive scrapes.
+func (c *solanaCollector) trackMinorityFork(lastVote, root, networkFinalized int64) bool {
+	voting := lastVote > c.forkLastVote
+	c.forkLastVote = lastVote
+
+	if voting && networkFinalized-root > minorityForkRootLag {
+		c.forkScrapes++
+	} else {
+		c.forkScrapes = 0
+	}
+	return c.forkScrapes >= minorityForkScrapes
+}
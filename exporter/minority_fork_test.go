@@ -0,0 +1,61 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestTrackMinorityFork(t *testing.T) {
+	c := &solanaCollector{}
+
+	// root of validator keeps up with the network
+	for i := int64(1); i <= 5; i++ {
+		if c.trackMinorityFork(1000+i, 968+i, 1000+i) {
+			t.Fatal("Expected no minority fork while root follows the network")
+		}
+	}
+
+	// validator keeps voting but its root stalls while the network finalizes
+	var onFork bool
+	for i := int64(1); i <= minorityForkScrapes; i++ {
+		onFork = c.trackMinorityFork(1005+i, 973, 1200+i)
+	}
+	if !onFork {
+		t.Error("Expected minority fork after sustained divergence of roots")
+	}
+
+	// validator stops voting, which is reported by delinquency instead
+	if c.trackMinorityFork(1008, 973, 1300) {
+		t.Error("Expected no minority fork when validator is not voting")
+	}
+}
+
+func TestCollectMinorityFork(t *testing.T) {
+	results := testRPCResults()
+	srv := newTestRPCServer(t, results)
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+
+	before := alertsSent(t, alerter.EventMinorityFork, alerter.Critical)
+
+	// network finalizes far ahead of the stalled root of validator which keeps voting
+	results["getSlot"] = `5000`
+	var value float64
+	for i := 1; i <= minorityForkScrapes; i++ {
+		results["getVoteAccounts"] = strings.Replace(testVoteAccounts, `"lastVote": 1000`, fmt.Sprintf(`"lastVote": %d`, 1000+i), 1)
+		fork := collectMetrics(t, c)["solana_validator_on_minority_fork"]
+		if len(fork) != 1 {
+			t.Fatalf("Expected minority fork metric, got %v", fork)
+		}
+		value = fork[0].GetGauge().GetValue()
+	}
+
+	if value != 1 {
+		t.Errorf("Expected validator on minority fork, got %v", value)
+	}
+	if got := alertsSent(t, alerter.EventMinorityFork, alerter.Critical) - before; got != 1 {
+		t.Errorf("Expected 1 minority fork alert, got %v", got)
+	}
+}
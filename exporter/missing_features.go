@@ -0,0 +1,78 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// featureActivations holds the configured features which are activated, fetched once per epoch as features are
+// only activated at an epoch boundary
+type featureActivations struct {
+	epoch     int64
+	activated map[string]int64
+}
+
+// missingActivatedFeatures returns the activated features whose configured minimum version is newer than the given
+// version of the node, in order
+func missingActivatedFeatures(activated map[string]int64, featureVersions map[string]string, version string) []string {
+	var missing []string
+	for id := range activated {
+		if required, ok := featureVersions[id]; ok && compareVersions(version, required) < 0 {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// emitMissingFeatures exports the number of activated features which the given version of the node likely lacks,
+// per the configured minimum version of each feature, and sends an alert when there are any, as the node risks
+// a partition from the cluster
+func (c *solanaCollector) emitMissingFeatures(ch chan<- prometheus.Metric, version string) {
+	features := c.config.Upgrade.Features
+	if len(features) == 0 {
+		return
+	}
+	epochInfo, err := c.getCachedEpochInfo()
+	if err != nil {
+		return
+	}
+
+	epoch := epochInfo.Result.Epoch
+	if c.featureActivations == nil || c.featureActivations.epoch != epoch {
+		ids := make([]string, 0, len(features))
+		for _, f := range features {
+			ids = append(ids, f.ID)
+		}
+		activated, err := monitor.GetFeatureActivations(c.config, utils.Network, ids)
+		if err != nil {
+			log.Printf("Error while getting feature activations : %v", err)
+			return
+		}
+		c.featureActivations = &featureActivations{epoch: epoch, activated: activated}
+	}
+
+	featureVersions := make(map[string]string, len(features))
+	for _, f := range features {
+		featureVersions[f.ID] = f.MinVersion
+	}
+	missing := missingActivatedFeatures(c.featureActivations.activated, featureVersions, version)
+	ch <- prometheus.MustNewConstMetric(c.missingActivatedFeatures, prometheus.GaugeValue, float64(len(missing)))
+
+	if len(missing) > 0 && !c.missingFeaturesAlerted {
+		err := alerter.SendAlert(alerter.EventMissingFeatures, fmt.Sprintf("Missing Features Alert : Your node runs version %s which likely lacks %d activated feature(s) %s, upgrade it to avoid a partition from the cluster",
+			version, len(missing), strings.Join(missing, ", ")), alerter.Critical, c.config)
+		if err != nil {
+			log.Printf("Error while sending missing features alert: %v", err)
+		}
+	}
+	c.missingFeaturesAlerted = len(missing) > 0
+}
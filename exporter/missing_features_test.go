@@ -0,0 +1,48 @@
+package exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestMissingActivatedFeatures(t *testing.T) {
+	activated := map[string]int64{"featureA": 1000, "featureB": 900, "featureD": 800}
+	versions := map[string]string{"featureA": "1.16.0", "featureB": "1.14.0", "featureC": "1.17.0"}
+
+	// featureC isn't activated yet and featureD has no configured version
+	if got := missingActivatedFeatures(activated, versions, "1.14.17"); !reflect.DeepEqual(got, []string{"featureA"}) {
+		t.Errorf("Expected featureA to be missing on 1.14.17, got %v", got)
+	}
+	if got := missingActivatedFeatures(activated, versions, "1.16.0"); len(got) != 0 {
+		t.Errorf("Expected no missing features on 1.16.0, got %v", got)
+	}
+}
+
+func TestCollectMissingFeatures(t *testing.T) {
+	results := testRPCResults()
+	// featureA is activated at slot 1000, featureB is activated and featureC is pending, in the order of the ids
+	results["getMultipleAccounts"] = `{"context":{"slot":1010},"value":[
+		{"data":["AegDAAAAAAAA","base64"],"owner":"Feature111111111111111111111111111111111111","lamports":1,"executable":false,"rentEpoch":0},
+		{"data":["AegDAAAAAAAA","base64"],"owner":"Feature111111111111111111111111111111111111","lamports":1,"executable":false,"rentEpoch":0},
+		{"data":["AAAAAAAAAAAA","base64"],"owner":"Feature111111111111111111111111111111111111","lamports":1,"executable":false,"rentEpoch":0}
+	]}`
+	srv := newTestRPCServer(t, results)
+
+	cfg := newTestConfig(srv.URL)
+	cfg.Upgrade.Features = []config.Feature{{ID: "featureA", MinVersion: "1.16.0"}, {ID: "featureB", MinVersion: "1.14.0"}, {ID: "featureC", MinVersion: "1.17.0"}}
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, alerter.EventMissingFeatures, alerter.Critical)
+	for i := 0; i < 2; i++ {
+		ms := collectMetrics(t, c)["solana_missing_activated_features"]
+		if len(ms) != 1 || ms[0].GetGauge().GetValue() != 1 {
+			t.Fatalf("Expected 1 missing activated feature, got %v", ms)
+		}
+	}
+	if got := alertsSent(t, alerter.EventMissingFeatures, alerter.Critical) - before; got != 1 {
+		t.Errorf("Expected 1 missing features alert, got %v", got)
+	}
+}
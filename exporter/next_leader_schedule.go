@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+// emitNextLeaderSchedule exports whether validator is in the leader schedule of the next epoch and sends an alert
+// when it is absent, e.g. because its stake is deactivating. The schedule is large, so it is fetched once per epoch
+// and nothing is exported until the rpc knows it.
+func (c *solanaCollector) emitNextLeaderSchedule(ch chan<- prometheus.Metric) {
+	epochInfo, err := c.getCachedEpochInfo()
+	if err != nil {
+		log.Printf("Error while getting epoch info : %v", err)
+		return
+	}
+
+	next := epochInfo.Result.Epoch + 1
+	if c.nextScheduleEpoch != next {
+		nextSlot := epochInfo.Result.AbsoluteSlot - epochInfo.Result.SlotIndex + epochInfo.Result.SlotsInEpoch
+		sch, err := monitor.GetLeaderSchedule(nextSlot, c.config)
+		if err != nil {
+			log.Printf("Error while getting leader schedule of next epoch : %v", err)
+			return
+		}
+		if sch.Result == nil {
+			return
+		}
+		c.nextScheduleEpoch = next
+		c.nextScheduleHasValidator = len(sch.Result[c.config.ValDetails.PubKey]) > 0
+	}
+
+	value := float64(0)
+	if c.nextScheduleHasValidator {
+		value = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.inNextLeaderSchedule, prometheus.GaugeValue, value)
+
+	absent := !c.nextScheduleHasValidator
+	if absent && !c.nextScheduleAlerted {
+		err := alerter.SendAlert(alerter.EventNextLeaderSchedule, "Leader Schedule Alert : Your validator is not in the leader schedule of the next epoch, it won't produce any blocks then",
+			alerter.Critical, c.config)
+		if err != nil {
+			log.Printf("Error while sending next leader schedule alert: %v", err)
+		}
+	}
+	c.nextScheduleAlerted = absent
+}
@@ -0,0 +1,44 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestCollectNextLeaderSchedule(t *testing.T) {
+	for _, tc := range []struct {
+		schedule string
+		expected []float64
+		alerts   float64
+	}{
+		{`{"valPubKey": [0, 1, 2, 3], "otherPubKey": [4, 5, 6, 7]}`, []float64{1}, 0},
+		// our identity is absent, which is alerted once
+		{`{"otherPubKey": [0, 1, 2, 3]}`, []float64{0}, 1},
+		// the schedule of the next epoch is not known yet
+		{`null`, nil, 0},
+	} {
+		results := testRPCResults()
+		results["getLeaderSchedule"] = tc.schedule
+		srv := newTestRPCServer(t, results)
+		c := NewSolanaCollector(newTestConfig(srv.URL))
+
+		before := alertsSent(t, alerter.EventNextLeaderSchedule, alerter.Critical)
+
+		for i := 0; i < 2; i++ {
+			ms := collectMetrics(t, c)["solana_validator_in_next_leader_schedule"]
+			if len(ms) != len(tc.expected) {
+				t.Fatalf("Expected %d in next leader schedule metrics for %s, got %d", len(tc.expected), tc.schedule, len(ms))
+			}
+			for j, m := range ms {
+				if got := m.GetGauge().GetValue(); got != tc.expected[j] {
+					t.Errorf("Expected in next leader schedule %v for %s, got %v", tc.expected[j], tc.schedule, got)
+				}
+			}
+		}
+
+		if got := alertsSent(t, alerter.EventNextLeaderSchedule, alerter.Critical) - before; got != tc.alerts {
+			t.Errorf("Expected %v next leader schedule alerts for %s, got %v", tc.alerts, tc.schedule, got)
+		}
+	}
+}
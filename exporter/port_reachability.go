@@ -0,0 +1,220 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// defaultPortProbeTimeout is the timeout of probing a port if not configured
+const defaultPortProbeTimeout = 3 * time.Second
+
+// defaultPortProbeInterval is how often the ports are probed if not configured
+const defaultPortProbeInterval = time.Minute
+
+// defaultCriticalPorts are the ports whose unreachability is alerted if not configured, a validator can't take
+// part in the cluster without them while the rpc port is often closed on purpose
+var defaultCriticalPorts = []string{"gossip", "tpu"}
+
+// nodePorts returns the gossip, tpu and rpc addresses the node with the given identity advertises in the cluster
+// nodes, ports it doesn't advertise e.g. a private rpc are left out
+func nodePorts(nodes *types.ClustrNode, pubKey string) map[string]string {
+	ports := make(map[string]string)
+	for _, node := range nodes.Result {
+		if node.Pubkey != pubKey {
+			continue
+		}
+		for port, addr := range map[string]string{"gossip": node.Gossip, "tpu": node.Tpu, "rpc": node.RPC} {
+			if addr != "" {
+				ports[port] = addr
+			}
+		}
+	}
+	return ports
+}
+
+// isCriticalPort returns whether the given port is among the critical ports
+func isCriticalPort(critical []string, port string) bool {
+	for _, p := range critical {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
+
+// probePort returns whether the given port of the node is reachable. Gossip and rpc ports accept tcp connections,
+// the gossip port through the ip echo server of the node. The tpu port only speaks udp, which has no handshake,
+// so it only counts as unreachable when the host actively refuses a datagram. A firewall silently dropping it
+// can't be told apart from a reachable port.
+func probePort(port, addr string, timeout time.Duration) bool {
+	if port != "tpu" {
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+
+	conn, err := net.DialTimeout("udp", addr, timeout)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte{0}); err != nil {
+		return false
+	}
+	// a refused datagram is reported by the following read, no answer is expected otherwise
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	_, err = conn.Read(make([]byte, 1))
+	return !errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// portProber probes the ports of the node in the background, as a silent udp port takes the whole timeout to
+// probe, and keeps the last results for Collect to export
+type portProber struct {
+	mu sync.Mutex
+	// addresses of the ports to probe, as last advertised in the cluster nodes
+	ports map[string]string
+	// whether each port was reachable in the last probe
+	reachable map[string]bool
+	// requests a probe before the next interval, e.g. when the advertised ports changed
+	trigger chan struct{}
+}
+
+func newPortProber() *portProber {
+	return &portProber{trigger: make(chan struct{}, 1)}
+}
+
+// setPorts sets the addresses of the ports to probe, a probe is requested right away when they changed
+func (p *portProber) setPorts(ports map[string]string) {
+	p.mu.Lock()
+	changed := fmt.Sprint(p.ports) != fmt.Sprint(ports)
+	p.ports = ports
+	p.mu.Unlock()
+
+	if changed {
+		select {
+		case p.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// results returns the addresses of the ports and whether each of them was reachable in the last probe
+func (p *portProber) results() (map[string]string, map[string]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ports, p.reachable
+}
+
+// probe probes the ports concurrently and stores the results
+func (p *portProber) probe(timeout time.Duration) {
+	p.mu.Lock()
+	ports := p.ports
+	p.mu.Unlock()
+
+	reachable := make(map[string]bool, len(ports))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for port, addr := range ports {
+		wg.Add(1)
+		go func(port, addr string) {
+			defer wg.Done()
+			ok := probePort(port, addr, timeout)
+			mu.Lock()
+			reachable[port] = ok
+			mu.Unlock()
+		}(port, addr)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	p.reachable = reachable
+	p.mu.Unlock()
+}
+
+// WatchPorts probes the ports of the node every configured interval, or as soon as Collect finds the advertised
+// ports changed. Nothing is probed unless enabled, as it is active network traffic.
+func (c *solanaCollector) WatchPorts(cfg *config.Config) {
+	interval := defaultPortProbeInterval
+	if d, err := time.ParseDuration(cfg.PortProbe.Interval); err == nil && d > 0 {
+		interval = d
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.portProber.trigger:
+		}
+		if !cfg.PortProbe.EnablePortProbe {
+			continue
+		}
+		timeout := defaultPortProbeTimeout
+		if d, err := time.ParseDuration(cfg.PortProbe.Timeout); err == nil && d > 0 {
+			timeout = d
+		}
+		c.portProber.probe(timeout)
+	}
+}
+
+// emitPortReachability hands the ports the node advertises in the cluster nodes to the background prober, exports
+// whether each of them was reachable in the last probe and sends an alert when a critical port becomes unreachable.
+// Nothing is exported unless probing is enabled.
+func (c *solanaCollector) emitPortReachability(ch chan<- prometheus.Metric) {
+	probe := c.config.PortProbe
+	if !probe.EnablePortProbe {
+		return
+	}
+	nodes, err := c.getCachedClusterNodes()
+	if err != nil {
+		log.Printf("Error while getting cluster nodes : %v", err)
+		return
+	}
+	c.portProber.setPorts(nodePorts(nodes, c.config.ValDetails.PubKey))
+	ports, reachable := c.portProber.results()
+
+	critical := probe.CriticalPorts
+	if len(critical) == 0 {
+		critical = defaultCriticalPorts
+	}
+	if c.portAlerted == nil {
+		c.portAlerted = make(map[string]bool)
+	}
+	for port, ok := range reachable {
+		// ports no longer advertised are left out until the next probe
+		if _, advertised := ports[port]; !advertised {
+			continue
+		}
+		v := float64(0)
+		if ok {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.nodePortReachable, prometheus.GaugeValue, v, port)
+
+		if !isCriticalPort(critical, port) {
+			continue
+		}
+		if !ok && !c.portAlerted[port] {
+			err := alerter.SendAlert(alerter.EventPortUnreachable, fmt.Sprintf("Port Alert : The %s port %s of your node is not reachable from the monitor host",
+				port, ports[port]), alerter.Critical, c.config)
+			if err != nil {
+				log.Printf("Error while sending port reachability alert: %v", err)
+			}
+		}
+		c.portAlerted[port] = !ok
+	}
+}
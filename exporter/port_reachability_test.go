@@ -0,0 +1,83 @@
+package exporter
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestCollectPortReachability(t *testing.T) {
+	// gossip and rpc are served by a local listener
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Error while listening :", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	// tpu points at a closed udp port
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Error while listening :", err)
+	}
+	closed := udp.LocalAddr().String()
+	udp.Close()
+
+	results := testRPCResults()
+	results["getClusterNodes"] = fmt.Sprintf(`[
+		{"pubkey": "valPubKey", "gossip": %[1]q, "tpu": %[2]q, "rpc": %[1]q, "version": "1.14.17"},
+		{"pubkey": "otherPubKey", "gossip": "127.0.0.1:1", "tpu": "127.0.0.1:1", "rpc": null, "version": "1.14.17"}
+	]`, ln.Addr().String(), closed)
+	srv := newTestRPCServer(t, results)
+	cfg := newTestConfig(srv.URL)
+	c := NewSolanaCollector(cfg)
+
+	// nothing is probed unless enabled
+	if m := collectMetrics(t, c)["solana_node_port_reachable"]; len(m) != 0 {
+		t.Fatalf("Expected no port reachability without probing enabled, got %v", m)
+	}
+
+	cfg.PortProbe.EnablePortProbe = true
+	before := alertsSent(t, alerter.EventPortUnreachable, alerter.Critical)
+
+	// the scrape only hands the ports to the background prober, nothing is exported before they are probed
+	if m := collectMetrics(t, c)["solana_node_port_reachable"]; len(m) != 0 {
+		t.Fatalf("Expected no port reachability before probing, got %v", m)
+	}
+	select {
+	case <-c.portProber.trigger:
+	default:
+		t.Fatal("Expected a probe to be requested for the new ports")
+	}
+	c.portProber.probe(defaultPortProbeTimeout)
+
+	for i := 0; i < 2; i++ {
+		reachable := make(map[string]float64)
+		for _, m := range collectMetrics(t, c)["solana_node_port_reachable"] {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "port" {
+					reachable[l.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+		expected := map[string]float64{"gossip": 1, "rpc": 1, "tpu": 0}
+		if fmt.Sprint(reachable) != fmt.Sprint(expected) {
+			t.Errorf("Expected port reachability %v, got %v", expected, reachable)
+		}
+	}
+
+	// the unreachable tpu port is alerted once
+	if got := alertsSent(t, alerter.EventPortUnreachable, alerter.Critical) - before; got != 1 {
+		t.Errorf("Expected 1 port alert, got %v", got)
+	}
+}
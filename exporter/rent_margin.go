@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+// rentMarginInterval is how often the rent margin of the vote account is checked, its balance changes slowly
+// compared to the identity balance fetched on every tick
+const rentMarginInterval = time.Minute
+
+// checkRentMargin exports the lamports the given account holds above its rent exempt minimum and sends an alert
+// when the balance falls below it, as the account is then no longer rent exempt
+func (c *solanaCollector) checkRentMargin(cfg *config.Config, account string, lamports, minimum int64) {
+	margin := lamports - minimum
+	rentMarginLamports.WithLabelValues(account).Set(float64(margin))
+
+	below := margin < 0
+	if below && !c.rentMarginAlerted[account] {
+		err := alerter.SendAlert(alerter.EventRentMargin, fmt.Sprintf("Rent Margin Alert : Balance %s of your %s account is %s below its rent exempt minimum %s",
+			alerter.FormatAmount(float64(lamports), cfg), account, alerter.FormatAmount(float64(-margin), cfg), alerter.FormatAmount(float64(minimum), cfg)), alerter.Critical, cfg)
+		if err != nil {
+			log.Printf("Error while sending rent margin alert: %v", err)
+		}
+	}
+	if c.rentMarginAlerted == nil {
+		c.rentMarginAlerted = make(map[string]bool)
+	}
+	c.rentMarginAlerted[account] = below
+}
+
+// watchRentMargin checks the rent margin of the identity account with its given balance and of the vote account
+// at most once per rentMarginInterval
+func (c *solanaCollector) watchRentMargin(cfg *config.Config, identityLamports int64) {
+	if minimum, err := monitor.GetMinimumBalanceForRentExemption(cfg, monitor.IdentityAccountSize); err != nil {
+		log.Printf("Error while getting rent exempt minimum of identity account : %v", err)
+	} else {
+		c.checkRentMargin(cfg, "identity", identityLamports, minimum)
+	}
+
+	if time.Since(c.rentCheckedAt) < rentMarginInterval {
+		return
+	}
+	c.rentCheckedAt = time.Now()
+
+	bal, err := monitor.GetVoteAccBalance(cfg)
+	if err != nil {
+		log.Printf("Error while getting vote account balance : %v", err)
+		return
+	}
+	minimum, err := monitor.GetMinimumBalanceForRentExemption(cfg, monitor.VoteAccountSize)
+	if err != nil {
+		log.Printf("Error while getting rent exempt minimum of vote account : %v", err)
+		return
+	}
+	c.checkRentMargin(cfg, "vote", bal.Result.Value, minimum)
+}
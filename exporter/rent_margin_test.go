@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestCheckRentMargin(t *testing.T) {
+	cfg := newTestConfig("http://localhost")
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, alerter.EventRentMargin, alerter.Critical)
+
+	margin := func() float64 {
+		var m dto.Metric
+		if err := rentMarginLamports.WithLabelValues("vote").Write(&m); err != nil {
+			t.Fatal("Error while reading rent margin :", err)
+		}
+		return m.GetGauge().GetValue()
+	}
+
+	// rent exempt minimum of a vote account
+	const minimum = 27074400
+
+	c.checkRentMargin(cfg, "vote", 30000000, minimum)
+	if got := margin(); got != 2925600 {
+		t.Errorf("Expected rent margin of 2925600 lamports, got %v", got)
+	}
+
+	c.checkRentMargin(cfg, "vote", 27000000, minimum)
+	if got := margin(); got != -74400 {
+		t.Errorf("Expected rent margin of -74400 lamports, got %v", got)
+	}
+	// no repeated alert while the balance stays below the minimum
+	c.checkRentMargin(cfg, "vote", 26990000, minimum)
+
+	if got := alertsSent(t, alerter.EventRentMargin, alerter.Critical) - before; got != 1 {
+		t.Errorf("Expected 1 rent margin alert, got %v", got)
+	}
+}
@@ -0,0 +1,36 @@
+package exporter
+
+// scrape results counted by solana_scrapes_total
+const (
+	scrapeSuccess = "success"
+	scrapePartial = "partial"
+	scrapeFailure = "failure"
+)
+
+// scrapeCalls counts the outcomes of the rpc calls of a scrape
+type scrapeCalls struct {
+	ok     int
+	failed int
+}
+
+// record counts the outcome of an rpc call, failed if err is not nil
+func (s *scrapeCalls) record(err error) {
+	if err != nil {
+		s.failed++
+		return
+	}
+	s.ok++
+}
+
+// result returns whether the scrape succeeded, failed completely or partially i.e., some of its rpc calls failed
+// while others succeeded
+func (s *scrapeCalls) result() string {
+	switch {
+	case s.failed == 0:
+		return scrapeSuccess
+	case s.ok == 0:
+		return scrapeFailure
+	default:
+		return scrapePartial
+	}
+}
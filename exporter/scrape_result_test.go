@@ -0,0 +1,50 @@
+package exporter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCollectScrapeResult(t *testing.T) {
+	results := testRPCResults()
+	results["getFirstAvailableBlock"] = `43000000`
+	srv := newTestRPCServer(t, results)
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+
+	scrapes := func() map[string]float64 {
+		counts := make(map[string]float64)
+		for _, m := range collectMetrics(t, c)["solana_scrapes_total"] {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "result" {
+					counts[l.GetValue()] = m.GetCounter().GetValue()
+				}
+			}
+		}
+		return counts
+	}
+
+	if got := scrapes(); got[scrapeSuccess] != 1 || got[scrapePartial] != 0 || got[scrapeFailure] != 0 {
+		t.Errorf("Expected 1 successful scrape, got %v", got)
+	}
+
+	// one of several calls fails
+	delete(results, "getVoteAccounts")
+	if got := scrapes(); got[scrapeSuccess] != 1 || got[scrapePartial] != 1 || got[scrapeFailure] != 0 {
+		t.Errorf("Expected 1 successful and 1 partial scrape, got %v", got)
+	}
+}
+
+func TestScrapeCallsResult(t *testing.T) {
+	var calls scrapeCalls
+	if got := calls.result(); got != scrapeSuccess {
+		t.Errorf("Expected %s without calls, got %s", scrapeSuccess, got)
+	}
+	calls.record(errors.New("rpc error"))
+	if got := calls.result(); got != scrapeFailure {
+		t.Errorf("Expected %s when all calls failed, got %s", scrapeFailure, got)
+	}
+	calls.record(nil)
+	if got := calls.result(); got != scrapePartial {
+		t.Errorf("Expected %s when some calls failed, got %s", scrapePartial, got)
+	}
+}
@@ -0,0 +1,80 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// scrapeSummary is the state of validator at the end of a scrape, logged as a single line with its key fields
+type scrapeSummary struct {
+	fields []string
+}
+
+// add appends the key=value field to the summary
+func (s *scrapeSummary) add(key string, value interface{}) {
+	s.fields = append(s.fields, fmt.Sprintf("%s=%v", key, value))
+}
+
+// String returns the summary line, e.g. Scrape summary : result=success duration=0.2s status=voting slot=1010
+func (s *scrapeSummary) String() string {
+	return "Scrape summary : " + strings.Join(s.fields, " ")
+}
+
+// votingStatus returns whether the vote account of the given identity is voting, not_voting or delinquent, and
+// unknown if it is not among the vote accounts
+func votingStatus(response types.GetVoteAccountsResponse, pubKey string) (status string, account *types.VoteAccount) {
+	for i, vote := range response.Result.Delinquent {
+		if vote.NodePubkey == pubKey {
+			return "delinquent", &response.Result.Delinquent[i]
+		}
+	}
+	for i, vote := range response.Result.Current {
+		if vote.NodePubkey == pubKey {
+			if vote.EpochVoteAccount {
+				return "voting", &response.Result.Current[i]
+			}
+			return "not_voting", &response.Result.Current[i]
+		}
+	}
+	return "unknown", nil
+}
+
+// summarizeScrape returns the summary of the scrape with the given result and duration from the vote accounts and
+// current slot it fetched, nil or -1 if they could not be fetched, and the balance last fetched by WatchSlots.
+// Fields which are not available are left out.
+func (c *solanaCollector) summarizeScrape(result string, took time.Duration, voteAccounts *types.GetVoteAccountsResponse, slot int64) *scrapeSummary {
+	s := &scrapeSummary{}
+	s.add("result", result)
+	s.add("duration", took.Round(time.Millisecond))
+
+	var account *types.VoteAccount
+	if voteAccounts != nil {
+		var status string
+		status, account = votingStatus(*voteAccounts, c.config.ValDetails.PubKey)
+		s.add("status", status)
+	}
+	if slot >= 0 {
+		s.add("slot", slot)
+	}
+	if account != nil {
+		if slot >= 0 {
+			s.add("vote_lag", slot-int64(account.LastVote))
+		}
+		if c.cachedEpochInfo != nil {
+			credits, _ := c.calcualteEpochVoteCredits(account.EpochCredits)
+			s.add("credits", int64(credits))
+		}
+	}
+	if !c.config.IsRPCNode() {
+		var m dto.Metric
+		if err := balance.Write(&m); err == nil {
+			s.add("balance_sol", fmt.Sprintf("%.4f", m.GetGauge().GetValue()))
+		}
+	}
+	return s
+}
@@ -0,0 +1,60 @@
+package exporter
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestCollectScrapeSummary(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+	balance.Set(1.5)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	collectMetrics(t, c)
+
+	var summary string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "Scrape summary : ") {
+			summary = line
+		}
+	}
+	// the last vote of the test vote account is 1000 at slot 1010
+	for _, field := range []string{"result=", "duration=", "status=voting", "slot=1010", "vote_lag=10", "credits=4000", "balance_sol=1.5000"} {
+		if !strings.Contains(summary, field) {
+			t.Errorf("Expected %s in scrape summary, got %q", field, summary)
+		}
+	}
+	// the rpc requests are only logged at the debug level
+	if strings.Contains(buf.String(), "Getting current slot") {
+		t.Errorf("Expected no rpc request logs at the info level, got %q", buf.String())
+	}
+}
+
+func TestVotingStatus(t *testing.T) {
+	var response types.GetVoteAccountsResponse
+	if status, _ := votingStatus(response, "valPubKey"); status != "unknown" {
+		t.Errorf("Expected voting status unknown without vote account, got %s", status)
+	}
+
+	response.Result.Current = []types.VoteAccount{{NodePubkey: "valPubKey", EpochVoteAccount: false}}
+	if status, _ := votingStatus(response, "valPubKey"); status != "not_voting" {
+		t.Errorf("Expected voting status not_voting, got %s", status)
+	}
+	response.Result.Current[0].EpochVoteAccount = true
+	if status, _ := votingStatus(response, "valPubKey"); status != "voting" {
+		t.Errorf("Expected voting status voting, got %s", status)
+	}
+
+	response.Result.Current, response.Result.Delinquent = nil, response.Result.Current
+	if status, _ := votingStatus(response, "valPubKey"); status != "delinquent" {
+		t.Errorf("Expected voting status delinquent, got %s", status)
+	}
+}
@@ -0,0 +1,78 @@
+package exporter
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// defaultServerTimeout is the read and write timeout of the metrics server if not configured
+const defaultServerTimeout = 30 * time.Second
+
+// NewMetricsServer returns the http server which serves the given metrics handler at /metrics on the
+// configured listen address, with the configured timeouts and basic auth. The status page is gathered from status.
+func NewMetricsServer(cfg *config.Config, metrics http.Handler, status prometheus.Gatherer) *http.Server {
+	p := cfg.Prometheus
+	if p.BasicAuthUsername != "" || p.BasicAuthPassword != "" {
+		metrics = basicAuth(metrics, p.BasicAuthUsername, p.BasicAuthPassword)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+	if len(p.StatusFields) > 0 {
+		// the status page is meant for delegators, so it is public
+		mux.Handle(StatusPath, StatusHandler(cfg, status))
+	}
+	if cfg.Slack.SigningSecret != "" {
+		// slack can't authenticate with basic auth, its requests are verified by their signature instead
+		mux.Handle(alerter.SlackInteractionsPath, alerter.SlackInteractionHandler(cfg))
+	}
+
+	return &http.Server{
+		Addr:         p.ListenAddress,
+		Handler:      mux,
+		ReadTimeout:  serverTimeout(p.ReadTimeout),
+		WriteTimeout: serverTimeout(p.WriteTimeout),
+	}
+}
+
+// ServeMetrics serves the metrics server over TLS when a certificate is configured, plain http otherwise
+func ServeMetrics(cfg *config.Config, srv *http.Server) error {
+	if cfg.Prometheus.CertFile != "" || cfg.Prometheus.KeyFile != "" {
+		return srv.ListenAndServeTLS(cfg.Prometheus.CertFile, cfg.Prometheus.KeyFile)
+	}
+	return srv.ListenAndServe()
+}
+
+// basicAuth wraps the given handler to reject requests without the given basic auth credentials
+func basicAuth(h http.Handler, username, password string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(u), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// serverTimeout parses the given timeout of the metrics server, defaults to 30s
+func serverTimeout(s string) time.Duration {
+	if s == "" {
+		return defaultServerTimeout
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid metrics server timeout %q, using default %s", s, defaultServerTimeout)
+		return defaultServerTimeout
+	}
+	return d
+}
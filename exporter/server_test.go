@@ -0,0 +1,53 @@
+package exporter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestMetricsServerBasicAuth(t *testing.T) {
+	cfg := &config.Config{Prometheus: config.Prometheus{
+		BasicAuthUsername: "prometheus",
+		BasicAuthPassword: "secret",
+		ReadTimeout:       "5s",
+	}}
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("solana_validator_active 1\n"))
+	})
+
+	srv := NewMetricsServer(cfg, metrics, prometheus.NewRegistry())
+	if srv.ReadTimeout != 5*time.Second || srv.WriteTimeout != defaultServerTimeout {
+		t.Errorf("Expected timeouts 5s and %s, got %s and %s", defaultServerTimeout, srv.ReadTimeout, srv.WriteTimeout)
+	}
+
+	ts := httptest.NewServer(srv.Handler)
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		username, password string
+		status             int
+	}{
+		{"", "", http.StatusUnauthorized},
+		{"prometheus", "wrong", http.StatusUnauthorized},
+		{"prometheus", "secret", http.StatusOK},
+	} {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/metrics", nil)
+		if tc.username != "" {
+			req.SetBasicAuth(tc.username, tc.password)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal("Error while requesting metrics :", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("Expected status %d with credentials %q:%q, got %d", tc.status, tc.username, tc.password, resp.StatusCode)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package exporter
 
 import (
-	"fmt"
 	"log"
 	"math"
 	"strings"
@@ -12,6 +11,7 @@ import (
 	"github.com/Chainflow/solana-mission-control/alerter"
 	"github.com/Chainflow/solana-mission-control/config"
 	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/types"
 	"github.com/Chainflow/solana-mission-control/utils"
 )
 
@@ -22,140 +22,196 @@ const (
 var (
 	confirmedSlotHeight = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_confirmed_slot_height",
-		Help: "Last confirmed slot height processed by watcher routine (max confirmation)",
+		Help: "Current slot of validator",
 	})
 
 	currentEpochNumber = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_confirmed_epoch_number",
-		Help: "Current epoch of validator (max confirmation)",
+		Help: "Current epoch of validator",
 	})
 
 	networkEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_network_epoch",
-		Help: "Current epoch of network (max confirmation)",
+		Help: "Current epoch of network",
 	})
 
 	epochDifference = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_epoch_diff",
-		Help: "Current epoch difference of network and validator (max confirmation)",
+		Help: "Difference of network and validator epoch",
 	})
 
 	epochFirstSlot = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_confirmed_epoch_first_slot",
-		Help: "Current epoch's first slot (max confirmation) - validator",
+		Help: "First slot of the current epoch of validator",
 	})
 
 	epochLastSlot = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_confirmed_epoch_last_slot",
-		Help: "Current epoch's last slot (max confirmation) - validator",
+		Help: "Last slot of the current epoch of validator",
 	})
 
 	networkEpochLastSlot = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_network_confirmed_epoch_last_slot",
-		Help: "Confirmed epoch's last slot (max confirmation) - network",
+		Help: "Last slot of the current epoch of network",
 	})
 
 	nodeHealth = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_node_health",
-		Help: "Current health of the node.",
+		Help: "Health of node, 1 if healthy else 0",
+	})
+
+	nodeSlotsBehind = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_node_slots_behind",
+		Help: "Number of slots the node is behind the cluster as reported by its health check, 0 if healthy or not reported",
+	})
+
+	catchupSlotsRemaining = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_validator_catchup_slots_remaining",
+		Help: "Number of slots the node still has to replay to catch up with the cluster, 0 if it is caught up",
+	})
+
+	catchupETA = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_validator_catchup_eta_seconds",
+		Help: "Estimated seconds until the node caught up with the cluster, 0 if it is caught up and -1 while the slots behind aren't decreasing",
 	})
 
 	balance = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "account_balance",
-		Help: "Current balance of your account.",
+		Name: "solana_account_balance_sol",
+		Help: "Balance of validator identity account in SOL",
 	})
 
 	leaderSlotsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "solana_leader_slots_total",
-			Help: "Number of leader slots per leader, grouped by skip status (max confirmation)",
+			Help: "Number of leader slots per leader, grouped by skip status",
 		},
 		[]string{"status", "nodekey"})
 
 	valBlockHeight = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_block_height",
-		Help: "Current Block Height of validator",
+		Help: "Current block height of validator",
 	})
 
 	networkBlockHeight = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_network_block_height",
-		Help: "Current Block Height of network",
+		Help: "Current block height of network",
 	})
 
 	blockDiff = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_block_height_diff",
-		Help: "Current Block Height difference of network and validator",
+		Help: "Difference of network and validator block height",
 	})
 
 	valSkipRate = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_val_skip_rate",
-		Help: "Validator skip rate",
+		Help: "Skip rate of validator in percent of its leader slots in current epoch",
 	})
 
 	netSkipRate = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_net_skip_rate",
-		Help: "Network skip rate",
+		Help: "Average skip rate of validators of network in percent in current epoch",
 	})
 
 	skipRateDifference = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_skip_rate_diff",
-		Help: "Skip rate difference of network and validator",
+		Help: "Difference of validator and network skip rate in percent",
 	})
 
 	leaderSlots = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_val_leader_slots",
-		Help: "Leader slots of a validator in current epoch",
+		Help: "Leader slots of validator so far in current epoch",
 	})
 
 	totalSlots = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_total_slots",
-		Help: "Total slots in current epoch",
+		Help: "Slots so far in current epoch",
 	})
 
 	valBlocksProduced = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_val_blocks_produced",
-		Help: "Blocks produced of a validator in current epoch",
+		Help: "Blocks produced by validator in current epoch",
 	})
 
+	blocksProducedByEpoch = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "solana_validator_blocks_produced_by_epoch",
+		Help: "Blocks produced by validator in each of the most recent epochs, labelled by epoch",
+	}, []string{"epoch"})
+
 	totalBlocksProduced = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_total_blocks_produced",
-		Help: "Total blocks produced in current epoch",
+		Help: "Blocks produced by network in current epoch",
 	})
 
 	skippdSlots = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_val_skipped_slots",
-		Help: "Skipped slots of a validator in current epoch",
+		Help: "Leader slots skipped by validator in current epoch",
 	})
 
 	skippedTotal = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "solana_skipped_total",
-		Help: "Total skipped slots of network in current epoch",
+		Help: "Slots skipped by network in current epoch",
+	})
+
+	solUSDPrice = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_sol_usd_price",
+		Help: "Current SOL price in USD from the configured price provider",
+	})
+
+	balanceUSD = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_account_balance_usd",
+		Help: "Balance of validator identity account in USD",
+	})
+
+	secondsSinceLastBlock = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_validator_seconds_since_last_block",
+		Help: "Seconds since validator last produced a block, since start of monitoring if it hasn't yet",
+	})
+
+	voteCostPerEpoch = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "solana_validator_vote_cost_lamports_per_epoch",
+		Help: "Estimated lamports spent from identity account on vote transactions per epoch",
 	})
+
+	rentMarginLamports = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "solana_account_rent_margin_lamports",
+		Help: "Lamports held by validator account above its rent exempt minimum balance, negative when below it",
+	}, []string{"account"})
 )
 
-func init() {
-	prometheus.MustRegister(confirmedSlotHeight)
-	prometheus.MustRegister(currentEpochNumber)
-	prometheus.MustRegister(epochFirstSlot)
-	prometheus.MustRegister(epochLastSlot)
-	prometheus.MustRegister(leaderSlotsTotal)
-	prometheus.MustRegister(nodeHealth)
-	prometheus.MustRegister(balance)
-	prometheus.MustRegister(valBlockHeight)
-	prometheus.MustRegister(networkBlockHeight)
-	prometheus.MustRegister(networkEpoch)
-	prometheus.MustRegister(epochDifference)
-	prometheus.MustRegister(blockDiff)
-	prometheus.MustRegister(valSkipRate)
-	prometheus.MustRegister(netSkipRate)
-	prometheus.MustRegister(skipRateDifference)
-	prometheus.MustRegister(leaderSlots)
-	prometheus.MustRegister(totalSlots)
-	prometheus.MustRegister(valBlocksProduced)
-	prometheus.MustRegister(totalBlocksProduced)
-	prometheus.MustRegister(skippdSlots)
-	prometheus.MustRegister(skippedTotal)
-	prometheus.MustRegister(networkEpochLastSlot)
+// RegisterMetrics registers the metrics updated by WatchSlots with the given registerer
+func RegisterMetrics(r prometheus.Registerer) {
+	r.MustRegister(confirmedSlotHeight)
+	r.MustRegister(currentEpochNumber)
+	r.MustRegister(epochFirstSlot)
+	r.MustRegister(epochLastSlot)
+	r.MustRegister(leaderSlotsTotal)
+	r.MustRegister(nodeHealth)
+	r.MustRegister(nodeSlotsBehind)
+	r.MustRegister(catchupSlotsRemaining)
+	r.MustRegister(catchupETA)
+	r.MustRegister(balance)
+	r.MustRegister(valBlockHeight)
+	r.MustRegister(networkBlockHeight)
+	r.MustRegister(networkEpoch)
+	r.MustRegister(epochDifference)
+	r.MustRegister(blockDiff)
+	r.MustRegister(valSkipRate)
+	r.MustRegister(netSkipRate)
+	r.MustRegister(skipRateDifference)
+	r.MustRegister(leaderSlots)
+	r.MustRegister(totalSlots)
+	r.MustRegister(valBlocksProduced)
+	r.MustRegister(blocksProducedByEpoch)
+	r.MustRegister(totalBlocksProduced)
+	r.MustRegister(skippdSlots)
+	r.MustRegister(skippedTotal)
+	r.MustRegister(networkEpochLastSlot)
+	r.MustRegister(solUSDPrice)
+	r.MustRegister(balanceUSD)
+	r.MustRegister(voteCostPerEpoch)
+	r.MustRegister(rentMarginLamports)
+	r.MustRegister(configLoadedTimestamp)
+	r.MustRegister(secondsSinceLastBlock)
 }
 
 // WatchSlots get data from different methods and store that data in prometheus. Those are
@@ -171,46 +227,69 @@ func init() {
 // 10. Leader slots and total slots of the validator
 // 11. Valid and total blocks produced
 // 12. Skipped slots and total slots skipped
+// 13. SOL price and USD valued account balance if enabled
+// 14. Estimated vote cost per epoch and send alert when it reaches the threshold
+// 15. Time since validator last produced a block and send alert when it is overdue
+// 16. Rent margin of identity and vote accounts and send alert when it goes negative
 func (c *solanaCollector) WatchSlots(cfg *config.Config) {
 	ticker := time.NewTicker(slotPacerSchedule)
 
 	for {
 		<-ticker.C
 
-		// Get identity account balance
-		bal, err := monitor.GetIdentityBalance(cfg)
-		if err != nil {
-			log.Printf("Error while getting account balance : %v", err)
-			// continue
-		}
+		var bal types.Balance
+		var balErr error
+		if !cfg.IsRPCNode() {
+			// Get identity account balance
+			bal, balErr = monitor.GetIdentityBalance(cfg)
+			if balErr != nil {
+				log.Printf("Error while getting account balance : %v", balErr)
+				// continue
+			}
 
-		balance.Set(float64(bal.Result.Value) / math.Pow(10, 9))
+			balance.Set(float64(bal.Result.Value) / math.Pow(10, 9))
+			if balErr == nil {
+				c.watchRentMargin(cfg, bal.Result.Value)
+			}
 
-		// Get skip rate of validator and network using solana cli command
-		valSkip, netSkip, err := monitor.SkipRate(cfg)
-		if err != nil {
-			log.Printf("Error while getting skipped slots : %v", err)
-			// continue
+			if cfg.Price.EnablePrice {
+				price, err := monitor.GetSOLPrice(cfg)
+				if err != nil {
+					log.Printf("Error while getting SOL price : %v", err)
+				} else {
+					solUSDPrice.Set(price)
+					balanceUSD.Set(float64(bal.Result.Value) / math.Pow(10, 9) * price)
+				}
+			}
+
+			// Get skip rate of validator and network using solana cli command
+			valSkip, netSkip, err := monitor.SkipRate(cfg)
+			if err != nil {
+				log.Printf("Error while getting skipped slots : %v", err)
+				// continue
+			}
+			valSkipRate.Set(valSkip)
+			netSkipRate.Set(netSkip)
+			skipdiff := valSkip - netSkip // skip rate difference of validator and network
+			skipRateDifference.Set(skipdiff)
+			log.Printf("Skip rate difference : %v", skipdiff)
 		}
-		valSkipRate.Set(valSkip)
-		netSkipRate.Set(netSkip)
-		skipdiff := valSkip - netSkip // skip rate difference of validator and network
-		skipRateDifference.Set(skipdiff)
-		log.Printf("Skip rate difference : %v", skipdiff)
 
 		// Get Node Health
-		h, err := monitor.GetNodeHealth(cfg)
+		h, behind, err := monitor.GetNodeHealth(cfg)
 		if err != nil {
 			log.Printf("Error while getting node health info : %v", err)
 			// continue
 		}
 
 		nodeHealth.Set(h) // set node health
+		nodeSlotsBehind.Set(float64(behind))
+		c.watchCatchup(cfg, h, behind, err)
 
 		// Get network epoch info
-		resp, err := monitor.GetEpochInfo(cfg, utils.Network)
-		if err != nil {
-			log.Printf("failed to fetch epoch info of network, retrying: %v", err)
+		resp, netErr := monitor.GetEpochInfo(cfg, utils.Network)
+		if netErr != nil {
+			log.Printf("failed to fetch epoch info of network, retrying: %v", netErr)
 			// continue
 		} else {
 			newEpoch := resp.Result.Epoch
@@ -219,33 +298,26 @@ func (c *solanaCollector) WatchSlots(cfg *config.Config) {
 			} else if *c.lastEpoch != newEpoch {
 				if strings.EqualFold(cfg.AlerterPreferences.NewEpochAlerts, "yes") {
 
-					activatedStake := float64(-1)
-					voteAccs, err := monitor.GetVoteAccounts(c.config, utils.Network)
-					if err != nil {
-						log.Printf("Error while getting vote accounts: %v", err)
-					} else {
-						for _, vote := range voteAccs.Result.Current {
-							if vote.NodePubkey == c.config.ValDetails.PubKey {
-								activatedStake = float64(vote.ActivatedStake) / math.Pow(10, 9)
-								break
+					msg := alerter.Localize(cfg, alerter.MsgNewEpoch, *c.lastEpoch, newEpoch)
+					if !cfg.IsRPCNode() {
+						activatedStake := float64(-1)
+						voteAccs, err := monitor.GetVoteAccounts(c.config, c.config.RPCSource(utils.StakeGroup, utils.Network))
+						if err != nil {
+							log.Printf("Error while getting vote accounts: %v", err)
+						} else {
+							for _, vote := range voteAccs.Result.Current {
+								if vote.NodePubkey == c.config.ValDetails.PubKey {
+									activatedStake = float64(vote.ActivatedStake) / math.Pow(10, 9)
+									break
+								}
 							}
 						}
+						msg = msg + alerter.Localize(cfg, alerter.MsgNewActivatedStake, activatedStake)
 					}
 
-					msg := fmt.Sprintf("New epoch started %d -> %d, new activated stake: %.4f", *c.lastEpoch, newEpoch, activatedStake)
-					err = alerter.SendTelegramAlert(msg, cfg)
-					if err != nil {
-						log.Printf("Error while sending new epoch alert to telegram: %v", err)
-					}
-					// send email alert
-					err = alerter.SendEmailAlert(msg, cfg)
+					err = alerter.SendAlert(alerter.EventNewEpoch, msg, alerter.Info, cfg)
 					if err != nil {
-						log.Printf("Error while sending new epoch alert to email: %v", err)
-					}
-					// send slack alert
-					err = alerter.SendSlackAlert(msg, cfg)
-					if err != nil {
-						log.Printf("Error while sending new epoch alert to slack: %v", err)
+						log.Printf("Error while sending new epoch alert: %v", err)
 					}
 				}
 				c.lastEpoch = &newEpoch
@@ -261,25 +333,32 @@ func (c *solanaCollector) WatchSlots(cfg *config.Config) {
 		networkEpochLastSlot.Set(float64(netLastSlot)) // set confirmed epoch last slock - network
 
 		// Get recent block production details
-		bp, err := monitor.BlockProduction(cfg)
-		if err != nil {
-			log.Printf("Error while getting block production details : %v", err)
-		}
+		if !cfg.IsRPCNode() {
+			bp, err := monitor.BlockProduction(cfg)
+			if err != nil {
+				log.Printf("Error while getting block production details : %v", err)
+			}
 
-		leaderSlots.Set(float64(bp.LeaderSlots))
-		totalSlots.Set(float64(bp.TotalSlots))
-		valBlocksProduced.Set(float64(bp.BlocksProduced))
-		totalBlocksProduced.Set(float64(bp.TotalBlocksProduced))
-		skippdSlots.Set(float64(bp.SkippedSlots))
-		skippedTotal.Set(float64(bp.TotalSlotsSkipped))
+			leaderSlots.Set(float64(bp.LeaderSlots))
+			totalSlots.Set(float64(bp.TotalSlots))
+			valBlocksProduced.Set(float64(bp.BlocksProduced))
+			totalBlocksProduced.Set(float64(bp.TotalBlocksProduced))
+			skippdSlots.Set(float64(bp.SkippedSlots))
+			skippedTotal.Set(float64(bp.TotalSlotsSkipped))
+
+			if err == nil {
+				c.trackBlockProduction(cfg, bp, resp.Result.Epoch, resp.Result.SlotIndex)
+				c.recordBlockHistory(cfg, resp.Result.Epoch, bp.BlocksProduced)
+			}
+		}
 
 		// Get validator epoch info
-		resp, err = monitor.GetEpochInfo(cfg, utils.Validator)
+		valResp, err := monitor.GetEpochInfo(cfg, utils.Validator)
 		if err != nil {
 			log.Printf("failed to fetch epoch info of validator, retrying: %v", err)
 			// continue
 		}
-		info := resp.Result
+		info := valResp.Result
 
 		// Calculate first and last slot in epoch.
 		firstSlot := info.AbsoluteSlot - info.SlotIndex
@@ -290,51 +369,47 @@ func (c *solanaCollector) WatchSlots(cfg *config.Config) {
 		epochLastSlot.Set(float64(lastSlot))
 		valBlockHeight.Set(float64(info.BlockHeight))
 
+		// Estimate vote cost from the identity balance
+		if !cfg.IsRPCNode() && balErr == nil && err == nil {
+			c.trackVoteCost(cfg, info.Epoch, info.SlotsInEpoch, int64(bal.Result.Context.Slot), bal.Result.Value)
+		}
+
 		log.Printf("Block Height: %d", info.BlockHeight)
 
+		// The differences of network and validator are only meaningful when both epoch infos were fetched,
+		// a failed call leaves a zero value response which would look like the validator fell far behind
+		if netErr != nil || err != nil {
+			continue
+		}
+
 		// Calculate epoch difference of network and validator
 		diff := float64(resp.Result.Epoch) - float64(info.Epoch)
 		epochDifference.Set(diff) // set epoch diff to prometheus
 
-		if strings.EqualFold(cfg.AlerterPreferences.EpochDiffAlerts, "yes") && int64(diff) >= cfg.AlertingThresholds.EpochDiffThreshold && int64(diff) > 0 {
-			// send alert
-			err = alerter.SendTelegramAlert(fmt.Sprintf("Epoch Difference Alert : Difference b/w network and validator epoch has exceeded the configured thershold %d", cfg.AlertingThresholds.EpochDiffThreshold), cfg)
-			if err != nil {
-				log.Printf("Error while sending epoch diff alert to telegram: %v", err)
-			}
-			// send email alert
-			err = alerter.SendEmailAlert(fmt.Sprintf("Epoch Difference Alert : Difference b/w network and validator epoch has exceeded the configured thershold %d", cfg.AlertingThresholds.EpochDiffThreshold), cfg)
-			if err != nil {
-				log.Printf("Error while sending epoch diff alert to email: %v", err)
-			}
-			// send slack alert
-			err = alerter.SendSlackAlert(fmt.Sprintf("Epoch Difference Alert : Difference b/w network and validator epoch has exceeded the configured thershold %d", cfg.AlertingThresholds.EpochDiffThreshold), cfg)
-			if err != nil {
-				log.Printf("Error while sending epoch diff alert to slack: %v", err)
-			}
-		}
+		alertEpochDiff(cfg, int64(diff))
 
 		heightDiff := float64(resp.Result.BlockHeight) - float64(info.BlockHeight)
 		blockDiff.Set(heightDiff) // block height difference of network and validator
 
 		if int64(heightDiff) >= cfg.AlertingThresholds.BlockDiffThreshold {
-			// send telegram alert
-			err = alerter.SendTelegramAlert(fmt.Sprintf("Block Difference Alert : Block difference b/w network and validator has exceeded %d", cfg.AlertingThresholds.BlockDiffThreshold), cfg)
+			// send alert
+			err = alerter.SendAlert(alerter.EventBlockDifference, alerter.Localize(cfg, alerter.MsgBlockDifference, cfg.AlertingThresholds.BlockDiffThreshold), alerter.Warning, cfg)
 			if err != nil {
-				log.Printf("Error while sending block height diff alert to telegram: %v", err)
+				log.Printf("Error while sending block height diff alert: %v", err)
 			}
+		}
+	}
+}
 
-			// send email alert
-			err = alerter.SendEmailAlert(fmt.Sprintf("Block Difference Alert : Block difference b/w network and validator has exceeded %d", cfg.AlertingThresholds.BlockDiffThreshold), cfg)
-			if err != nil {
-				log.Printf("Error while sending block height diff alert to email: %v", err)
-			}
+// alertEpochDiff sends an alert when the epoch difference of network and validator reaches or exceeds the
+// configured threshold and epoch difference alerts are enabled
+func alertEpochDiff(cfg *config.Config, diff int64) {
+	if !strings.EqualFold(cfg.AlerterPreferences.EpochDiffAlerts, "yes") || diff <= 0 || diff < cfg.AlertingThresholds.EpochDiffThreshold {
+		return
+	}
 
-			// send slack alert
-			err = alerter.SendSlackAlert(fmt.Sprintf("Block Difference Alert : Block difference b/w network and validator has exceeded %d", cfg.AlertingThresholds.BlockDiffThreshold), cfg)
-			if err != nil {
-				log.Printf("Error while sending block height diff alert to slack: %v", err)
-			}
-		}
+	err := alerter.SendAlert(alerter.EventEpochDifference, alerter.Localize(cfg, alerter.MsgEpochDifference, diff, cfg.AlertingThresholds.EpochDiffThreshold), alerter.Warning, cfg)
+	if err != nil {
+		log.Printf("Error while sending epoch diff alert: %v", err)
 	}
 }
@@ -0,0 +1,36 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestAlertEpochDiff(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.AlerterPreferences.EpochDiffAlerts = "yes"
+	cfg.AlertingThresholds.EpochDiffThreshold = 2
+
+	for _, tc := range []struct {
+		diff     int64
+		expected float64
+	}{
+		{diff: 0, expected: 0},
+		{diff: 1, expected: 0},
+		{diff: 2, expected: 1},
+		{diff: 3, expected: 1},
+	} {
+		before := alertsSent(t, "epoch_diff", "warning")
+		alertEpochDiff(cfg, tc.diff)
+		if got := alertsSent(t, "epoch_diff", "warning") - before; got != tc.expected {
+			t.Errorf("diff %d: expected %v epoch diff alerts, got %v", tc.diff, tc.expected, got)
+		}
+	}
+
+	cfg.AlerterPreferences.EpochDiffAlerts = "no"
+	before := alertsSent(t, "epoch_diff", "warning")
+	alertEpochDiff(cfg, 5)
+	if got := alertsSent(t, "epoch_diff", "warning") - before; got != 0 {
+		t.Errorf("Expected no epoch diff alert when disabled, got %v", got)
+	}
+}
@@ -0,0 +1,112 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// stakeActivationRefresh is the interval at which the stake accounts of validator are fetched again,
+// getProgramAccounts of the stake program is expensive so it is not called on every scrape
+const stakeActivationRefresh = 5 * time.Minute
+
+// stakeActivation holds the stake in SOL which is activating and deactivating in an epoch
+type stakeActivation struct {
+	epoch        int64
+	activating   float64
+	deactivating float64
+	// foundationStake is whether a stake account of a configured foundation authority delegates to validator
+	foundationStake bool
+	fetchedAt       time.Time
+}
+
+// emitStakeActivation exports the stake delegated to validator which is activating or deactivating in the
+// current epoch, i.e. joining or leaving the activated stake in the next epoch, the resulting stake of the next
+// epoch from the given activated stake in SOL, and sends an alert when the deactivating stake reaches the
+// configured threshold
+func (c *solanaCollector) emitStakeActivation(ch chan<- prometheus.Metric, epoch int64, activated float64) {
+	if c.stakeActivation == nil || c.stakeActivation.epoch != epoch || time.Since(c.stakeActivation.fetchedAt) >= stakeActivationRefresh {
+		accounts, err := monitor.GetStakeAccounts(c.config, c.config.RPCSource(utils.StakeGroup, utils.Network))
+		if err != nil {
+			log.Printf("Error while getting stake accounts : %v", err)
+			return
+		}
+		activating, deactivating := pendingStake(accounts, c.config.ValDetails.VoteKey, epoch)
+		foundation := hasFoundationStake(accounts, c.config.ValDetails.VoteKey, epoch, c.config.ValDetails.FoundationStakeAuthorities)
+		c.stakeActivation = &stakeActivation{epoch: epoch, activating: activating, deactivating: deactivating, foundationStake: foundation, fetchedAt: time.Now()}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.activatingStake, prometheus.GaugeValue, c.stakeActivation.activating)
+	ch <- prometheus.MustNewConstMetric(c.deactivatingStake, prometheus.GaugeValue, c.stakeActivation.deactivating)
+	ch <- prometheus.MustNewConstMetric(c.nextEpochStake, prometheus.GaugeValue,
+		nextEpochStake(activated, c.stakeActivation.activating, c.stakeActivation.deactivating))
+	c.emitFoundationStake(ch)
+
+	threshold := c.config.AlertingThresholds.DeactivatingStakeThresholdSOL
+	if threshold <= 0 || c.stakeActivation.deactivating < threshold || c.deactivationAlertEpoch == epoch {
+		return
+	}
+	c.deactivationAlertEpoch = epoch
+	err := alerter.SendAlert(alerter.EventDeactivatingStake, fmt.Sprintf("Deactivating Stake Alert : %s of stake delegated to your validator is deactivating in epoch %d and leaves with the next epoch",
+		alerter.FormatAmount(c.stakeActivation.deactivating*utils.LamportsPerSOL, c.config), epoch), alerter.Warning, c.config)
+	if err != nil {
+		log.Printf("Error while sending deactivating stake alert: %v", err)
+	}
+}
+
+// nextEpochStake returns the effective stake in SOL of the next epoch from the activated stake and the stake
+// activating and deactivating in the current epoch. It can't be negative, as the activated stake reported by
+// the rpc lags the stake accounts around the epoch boundary.
+func nextEpochStake(activated, activating, deactivating float64) float64 {
+	next := activated + activating - deactivating
+	if next < 0 {
+		return 0
+	}
+	return next
+}
+
+// pendingStake returns the stake in SOL of the delegations to the given vote account which is activating and
+// deactivating in the given epoch. The warmup and cooldown rate limit of the cluster is not applied, which only
+// spreads very large changes of the total stake of the cluster over several epochs.
+func pendingStake(accounts types.StakeAccounts, voteKey string, epoch int64) (activating, deactivating float64) {
+	for _, account := range accounts.Result {
+		stake := account.Account.Data.Parsed.Info.Stake
+		if stake == nil || stake.Delegation.Voter != voteKey {
+			continue
+		}
+		d := stake.Delegation
+		lamports, err := strconv.ParseUint(d.Stake, 10, 64)
+		if err != nil {
+			continue
+		}
+		// epochs of delegations which never (de)activate are u64 max and don't fit an int64
+		activation, err := strconv.ParseInt(d.ActivationEpoch, 10, 64)
+		if err != nil {
+			activation = math.MaxInt64
+		}
+		deactivation, err := strconv.ParseInt(d.DeactivationEpoch, 10, 64)
+		if err != nil {
+			deactivation = math.MaxInt64
+		}
+
+		sol := float64(lamports) / math.Pow(10, 9)
+		switch {
+		case activation == epoch && deactivation == epoch:
+			// delegated and undelegated in the same epoch, it never becomes active
+		case activation == epoch:
+			activating += sol
+		case deactivation == epoch:
+			deactivating += sol
+		}
+	}
+	return activating, deactivating
+}
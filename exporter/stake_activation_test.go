@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestPendingStake(t *testing.T) {
+	data := `{"jsonrpc": "2.0", "result": [
+		{"pubkey": "active", "account": {"data": {"parsed": {"type": "delegated", "info": {"stake": {"delegation": {"voter": "valVoteKey", "stake": "5000000000000", "activationEpoch": "80", "deactivationEpoch": "18446744073709551615"}}}}}}},
+		{"pubkey": "activating", "account": {"data": {"parsed": {"type": "delegated", "info": {"stake": {"delegation": {"voter": "valVoteKey", "stake": "250000000000", "activationEpoch": "100", "deactivationEpoch": "18446744073709551615"}}}}}}},
+		{"pubkey": "deactivating", "account": {"data": {"parsed": {"type": "delegated", "info": {"stake": {"delegation": {"voter": "valVoteKey", "stake": "1500000000000", "activationEpoch": "90", "deactivationEpoch": "100"}}}}}}},
+		{"pubkey": "deactivated", "account": {"data": {"parsed": {"type": "delegated", "info": {"stake": {"delegation": {"voter": "valVoteKey", "stake": "700000000000", "activationEpoch": "90", "deactivationEpoch": "99"}}}}}}},
+		{"pubkey": "canceled", "account": {"data": {"parsed": {"type": "delegated", "info": {"stake": {"delegation": {"voter": "valVoteKey", "stake": "300000000000", "activationEpoch": "100", "deactivationEpoch": "100"}}}}}}},
+		{"pubkey": "other", "account": {"data": {"parsed": {"type": "delegated", "info": {"stake": {"delegation": {"voter": "otherVoteKey", "stake": "900000000000", "activationEpoch": "100", "deactivationEpoch": "18446744073709551615"}}}}}}},
+		{"pubkey": "initialized", "account": {"data": {"parsed": {"type": "initialized", "info": {}}}}}
+	], "id": 1}`
+
+	var accounts types.StakeAccounts
+	if err := json.Unmarshal([]byte(data), &accounts); err != nil {
+		t.Fatal("Error while parsing stake accounts :", err)
+	}
+
+	activating, deactivating := pendingStake(accounts, "valVoteKey", 100)
+	if activating != 250 {
+		t.Errorf("Expected 250 SOL activating, got %v", activating)
+	}
+	if deactivating != 1500 {
+		t.Errorf("Expected 1500 SOL deactivating, got %v", deactivating)
+	}
+}
+
+func TestNextEpochStake(t *testing.T) {
+	for _, tc := range []struct {
+		name                                string
+		activated, activating, deactivating float64
+		want                                float64
+	}{
+		{"unchanged", 5000, 0, 0, 5000},
+		{"activating", 5000, 250, 0, 5250},
+		{"deactivating", 5000, 0, 1500, 3500},
+		{"both", 5000, 250, 1500, 3750},
+		{"all deactivating", 5000, 0, 5000, 0},
+		{"lagging activated stake", 1000, 0, 1500, 0},
+	} {
+		if got := nextEpochStake(tc.activated, tc.activating, tc.deactivating); got != tc.want {
+			t.Errorf("Expected %v SOL next epoch when %s, got %v", tc.want, tc.name, got)
+		}
+	}
+}
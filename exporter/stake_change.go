@@ -0,0 +1,56 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// alertStakeChange sends a delegation or undelegation alert when the activated stake of validator in SOL has
+// changed since the last scrape by at least one of the configured percentage or absolute thresholds
+func (c *solanaCollector) alertStakeChange(stake float64) {
+	prev := c.lastActivatedStake
+	c.lastActivatedStake = &stake
+
+	if prev == nil || !strings.EqualFold(c.config.AlerterPreferences.DelegationAlerts, "yes") {
+		return
+	}
+	if !stakeChangeExceeded(*prev, stake, c.config.AlertingThresholds) {
+		return
+	}
+
+	delta := stake - *prev
+	event, severity, direction := alerter.EventDelegation, alerter.Info, "increased"
+	if delta < 0 {
+		event, severity, direction = alerter.EventUndelegation, alerter.Warning, "decreased"
+	}
+
+	err := alerter.SendAlert(event, fmt.Sprintf("Stake Change Alert : Your activated stake has %s by %s from %s to %s",
+		direction, alerter.FormatAmount(math.Abs(delta)*utils.LamportsPerSOL, c.config), alerter.FormatAmount(*prev*utils.LamportsPerSOL, c.config),
+		alerter.FormatAmount(stake*utils.LamportsPerSOL, c.config)), severity, c.config)
+	if err != nil {
+		log.Printf("Error while sending stake change alert: %v", err)
+	}
+}
+
+// stakeChangeExceeded reports whether the change from prev to current stake reaches the percentage or the
+// absolute threshold, a threshold of 0 is disabled
+func stakeChangeExceeded(prev, current float64, thresholds config.AlertingThreshold) bool {
+	delta := math.Abs(current - prev)
+	if delta == 0 {
+		return false
+	}
+
+	if thresholds.StakeChangeAbsoluteThresholdSOL > 0 && delta >= thresholds.StakeChangeAbsoluteThresholdSOL {
+		return true
+	}
+	if thresholds.StakeChangePercentageThreshold > 0 && prev > 0 && delta/prev*100 >= thresholds.StakeChangePercentageThreshold {
+		return true
+	}
+	return false
+}
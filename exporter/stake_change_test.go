@@ -0,0 +1,45 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestStakeChangeExceeded(t *testing.T) {
+	thresholds := config.AlertingThreshold{StakeChangePercentageThreshold: 10, StakeChangeAbsoluteThresholdSOL: 1000}
+
+	for _, tc := range []struct {
+		prev, current float64
+		expected      bool
+	}{
+		{prev: 100000, current: 98500, expected: true},   // 1500 SOL is only 1.5% but exceeds the absolute threshold
+		{prev: 5000, current: 5600, expected: true},      // 600 SOL is 12%
+		{prev: 100000, current: 100500, expected: false}, // 500 SOL is 0.5%
+		{prev: 5000, current: 5000, expected: false},
+	} {
+		if got := stakeChangeExceeded(tc.prev, tc.current, thresholds); got != tc.expected {
+			t.Errorf("%v -> %v: expected %v, got %v", tc.prev, tc.current, tc.expected, got)
+		}
+	}
+
+	if stakeChangeExceeded(100000, 98500, config.AlertingThreshold{StakeChangePercentageThreshold: 10}) {
+		t.Error("Expected absolute swing not to alert with only the percentage threshold")
+	}
+}
+
+func TestAlertStakeChangeAbsolute(t *testing.T) {
+	cfg := newTestConfig("")
+	cfg.AlerterPreferences.DelegationAlerts = "yes"
+	cfg.AlertingThresholds.StakeChangePercentageThreshold = 10
+	cfg.AlertingThresholds.StakeChangeAbsoluteThresholdSOL = 1000
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, "undelegation", "warning")
+	c.alertStakeChange(100000)
+	c.alertStakeChange(98500)
+
+	if got := alertsSent(t, "undelegation", "warning") - before; got != 1 {
+		t.Errorf("Expected 1 undelegation alert on absolute swing, got %v", got)
+	}
+}
@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// maxStatsDPacket is the size up to which statsd lines are batched into a single udp packet, small enough
+// to avoid fragmentation on common networks
+const maxStatsDPacket = 1432
+
+// statsDInvalidChars matches the characters which are replaced in statsd metric names and tags
+var statsDInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.\-/]`)
+
+// WatchStatsD sends the metrics gathered from the given gatherer as statsd gauges to the configured statsd_address
+// on every scrape
+func WatchStatsD(cfg *config.Config, g prometheus.Gatherer) {
+	conn, err := net.Dial("udp", cfg.Prometheus.StatsDAddress)
+	if err != nil {
+		log.Printf("Error while connecting to statsd : %v", err)
+		return
+	}
+	defer conn.Close()
+
+	rate := scrapeRate(cfg)
+	for {
+		if err := SendStatsD(conn, g, cfg.Prometheus.StatsDTags); err != nil {
+			log.Printf("Error while sending metrics to statsd : %v", err)
+		}
+		time.Sleep(rate)
+	}
+}
+
+// SendStatsD gathers the current gauges and counters and writes them as statsd gauges to the given connection,
+// batched into packets of at most maxStatsDPacket bytes. Labels are sent as dogstatsd tags if tags is set,
+// otherwise their values are appended to the metric name.
+func SendStatsD(conn net.Conn, g prometheus.Gatherer, tags bool) error {
+	families, err := g.Gather()
+	if err != nil {
+		return err
+	}
+
+	var packet bytes.Buffer
+	flush := func() error {
+		if packet.Len() == 0 {
+			return nil
+		}
+		_, err := conn.Write(packet.Bytes())
+		packet.Reset()
+		return err
+	}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			line, ok := statsDLine(family.GetName(), family.GetType(), m, tags)
+			if !ok {
+				continue
+			}
+			if packet.Len() > 0 && packet.Len()+1+len(line) > maxStatsDPacket {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			if packet.Len() > 0 {
+				packet.WriteByte('\n')
+			}
+			packet.WriteString(line)
+		}
+	}
+	return flush()
+}
+
+// statsDLine formats a gauge, counter or untyped metric as statsd gauge line, ok is false for other metric types
+func statsDLine(name string, typ dto.MetricType, m *dto.Metric, tags bool) (line string, ok bool) {
+	value, ok := sampleValue(typ, m)
+	if !ok {
+		return "", false
+	}
+
+	labels := m.GetLabel()
+	sort.Slice(labels, func(i, j int) bool { return labels[i].GetName() < labels[j].GetName() })
+
+	var suffix string
+	if tags {
+		pairs := make([]string, 0, len(labels))
+		for _, l := range labels {
+			pairs = append(pairs, l.GetName()+":"+statsDInvalidChars.ReplaceAllString(l.GetValue(), "_"))
+		}
+		if len(pairs) > 0 {
+			suffix = "|#" + strings.Join(pairs, ",")
+		}
+	} else {
+		for _, l := range labels {
+			name += "." + statsDInvalidChars.ReplaceAllString(l.GetValue(), "_")
+		}
+	}
+	return fmt.Sprintf("%s:%s|g%s", name, strconv.FormatFloat(value, 'f', -1, 64), suffix), true
+}
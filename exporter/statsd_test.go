@@ -0,0 +1,61 @@
+package exporter
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSendStatsD(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewSolanaCollector(newTestConfig(srv.URL)))
+
+	for _, tc := range []struct {
+		tags     bool
+		expected []string
+	}{
+		{false, []string{"solana_tx_count:123456|g", "solana_validator_activated_stake_sol.valPubKey.valVoteKey:5000|g"}},
+		{true, []string{"solana_tx_count:123456|g", "solana_validator_activated_stake_sol:5000|g|#pubkey:valPubKey,votekey:valVoteKey"}},
+	} {
+		listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := net.Dial("udp", listener.LocalAddr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := SendStatsD(conn, reg, tc.tags); err != nil {
+			t.Fatal("Error while sending metrics to statsd :", err)
+		}
+
+		lines := make(map[string]bool)
+		buf := make([]byte, 65536)
+		for {
+			listener.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+			n, _, err := listener.ReadFrom(buf)
+			if err != nil {
+				break
+			}
+			if n > maxStatsDPacket {
+				t.Errorf("Expected packets of at most %d bytes, got %d", maxStatsDPacket, n)
+			}
+			for _, line := range strings.Split(string(buf[:n]), "\n") {
+				lines[line] = true
+			}
+		}
+		for _, line := range tc.expected {
+			if !lines[line] {
+				t.Errorf("Expected statsd line %q with tags %v", line, tc.tags)
+			}
+		}
+		conn.Close()
+		listener.Close()
+	}
+}
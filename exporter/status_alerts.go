@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"log"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// defaultStatusAlertWindow is how long after an alert timing its status alert is still sent if not configured
+const defaultStatusAlertWindow = 10 * time.Minute
+
+// statusAlertWindow returns the configured status alert window, defaultStatusAlertWindow if not configured or invalid
+func statusAlertWindow(cfg *config.Config) time.Duration {
+	w := cfg.RegularStatusAlerts.AlertWindow
+	if w == "" {
+		return defaultStatusAlertWindow
+	}
+	d, err := time.ParseDuration(w)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid status alert window %q, using default %s", w, defaultStatusAlertWindow)
+		return defaultStatusAlertWindow
+	}
+	return d
+}
+
+// statusAlertSchedule tracks the day on which each regular status alert timing last fired
+type statusAlertSchedule struct {
+	fired map[string]string
+}
+
+// due returns the alert timings e.g. 02:30PM which are due at the given time and haven't fired on their day yet,
+// and records them as fired. A timing is due from its time of day until the window has passed, which may be
+// after midnight. Invalid timings are ignored.
+func (s *statusAlertSchedule) due(timings []string, now time.Time, window time.Duration) []string {
+	if s.fired == nil {
+		s.fired = make(map[string]string)
+	}
+
+	var due []string
+	for _, timing := range timings {
+		t, err := time.Parse(time.Kitchen, timing)
+		if err != nil {
+			log.Printf("Invalid alert timing %q : %v", timing, err)
+			continue
+		}
+		slot := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if now.Before(slot) {
+			slot = slot.AddDate(0, 0, -1)
+		}
+		if now.Sub(slot) >= window {
+			continue
+		}
+		day := slot.Format("2006-01-02")
+		if s.fired[timing] == day {
+			continue
+		}
+		s.fired[timing] = day
+		due = append(due, timing)
+	}
+	return due
+}
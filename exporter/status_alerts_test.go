@@ -0,0 +1,53 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestStatusAlertScheduleDue(t *testing.T) {
+	var s statusAlertSchedule
+	timings := []string{"02:30PM", "11:55PM"}
+	day := time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		at       time.Duration
+		expected []string
+	}{
+		{14*time.Hour + 29*time.Minute, nil},
+		// the scrape skipped 02:30PM, the next one within the window still fires once
+		{14*time.Hour + 31*time.Minute, []string{"02:30PM"}},
+		{14*time.Hour + 32*time.Minute, nil},
+		// the window of 11:55PM reaches past midnight
+		{24*time.Hour + 2*time.Minute, []string{"11:55PM"}},
+		{24*time.Hour + 3*time.Minute, nil},
+		// fired timings are due again the next day, but not after the window
+		{24*time.Hour + 14*time.Hour + 45*time.Minute, nil},
+		{48*time.Hour + 14*time.Hour + 30*time.Minute, []string{"02:30PM"}},
+	} {
+		due := s.due(timings, day.Add(tc.at), 10*time.Minute)
+		if len(due) != len(tc.expected) || (len(due) > 0 && due[0] != tc.expected[0]) {
+			t.Errorf("Expected %v due at %s, got %v", tc.expected, day.Add(tc.at), due)
+		}
+	}
+}
+
+func TestAlertValidatorStatusAfterTiming(t *testing.T) {
+	cfg := newTestConfig("http://rpc.invalid")
+	// the first scrape lands one minute after the configured time
+	cfg.RegularStatusAlerts.AlertTimings = []string{time.Now().UTC().Add(-time.Minute).Format(time.Kitchen)}
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, alerter.EventValidatorStatus, alerter.Info)
+	ch := make(chan prometheus.Metric, 10)
+	for i := 0; i < 3; i++ {
+		c.AlertValidatorStatus("Solana validator is VOTING", alerter.Info, ch)
+	}
+	if got := alertsSent(t, alerter.EventValidatorStatus, alerter.Info) - before; got != 1 {
+		t.Errorf("Expected 1 validator status alert, got %v", got)
+	}
+}
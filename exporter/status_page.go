@@ -0,0 +1,120 @@
+package exporter
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// StatusPath is the path on the metrics server at which the public status of validator is served
+const StatusPath = "/status.json"
+
+// statusField selects the metric sample published as a field of the status page: the sample whose label has the
+// given value if label is set, and its value, the value of fromLabel if set, or whether the value is 1 if boolean
+type statusField struct {
+	metric            string
+	label, labelValue string
+	fromLabel         string
+	boolean           bool
+}
+
+// statusFields are the fields which may be published on the status page, a curated subset of the metrics
+// without keys, addresses or balances of the validator
+var statusFields = map[string]statusField{
+	"voting":               {metric: "solana_val_status", boolean: true},
+	"delinquent":           {metric: "solana_validator_delinquent", boolean: true},
+	"active":               {metric: "solana_validator_active", boolean: true},
+	"commission":           {metric: "solana_val_commission"},
+	"activated_stake_sol":  {metric: "solana_validator_activated_stake_sol"},
+	"skip_rate":            {metric: "solana_val_skip_rate"},
+	"vote_credits":         {metric: "solana_validator_vote_credits", label: "type", labelValue: "current"},
+	"network_vote_credits": {metric: "solana_network_vote_credits", label: "type", labelValue: "current"},
+	"estimated_apy":        {metric: "solana_validator_estimated_apy"},
+	"version":              {metric: "solana_node_version", fromLabel: "version"},
+}
+
+// statusHandler serves the configured status fields as json. It is gathered from the last scrape of the collector
+// at most once per scrape rate, so that requests to the public endpoint don't run the rpc calls of the collector.
+type statusHandler struct {
+	cfg *config.Config
+	g   prometheus.Gatherer
+
+	mu       sync.Mutex
+	body     []byte
+	gathered time.Time
+}
+
+// StatusHandler returns the handler of the status page with the configured status_fields gathered from g
+func StatusHandler(cfg *config.Config, g prometheus.Gatherer) http.Handler {
+	return &statusHandler{cfg: cfg, g: g}
+}
+
+// ServeHTTP writes the status of validator as json object
+func (h *statusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	if h.body == nil || time.Since(h.gathered) >= scrapeRate(h.cfg) {
+		body, err := json.Marshal(h.status())
+		if err != nil {
+			h.mu.Unlock()
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		h.body, h.gathered = body, time.Now()
+	}
+	body := h.body
+	h.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// status gathers the metrics and returns the configured status fields with the name of validator, fields whose
+// metric was not collected are left out
+func (h *statusHandler) status() map[string]interface{} {
+	mfs, err := h.g.Gather()
+	if err != nil {
+		log.Printf("Error while gathering metrics of status page : %v", err)
+	}
+
+	status := map[string]interface{}{"validator": h.cfg.ValDetails.ValidatorName}
+	for _, name := range h.cfg.Prometheus.StatusFields {
+		field, ok := statusFields[name]
+		if !ok {
+			continue
+		}
+		for _, mf := range mfs {
+			if mf.GetName() != field.metric {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				labels := make(map[string]string)
+				for _, l := range m.GetLabel() {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if field.label != "" && labels[field.label] != field.labelValue {
+					continue
+				}
+				value, ok := sampleValue(mf.GetType(), m)
+				if !ok {
+					continue
+				}
+				switch {
+				case field.fromLabel != "":
+					status[name] = labels[field.fromLabel]
+				case field.boolean:
+					status[name] = value == 1
+				default:
+					status[name] = value
+				}
+				break
+			}
+		}
+	}
+	return status
+}
@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestStatusHandler(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(srv.URL)
+	cfg.Prometheus.StatusFields = []string{"voting", "delinquent", "commission", "vote_credits", "version"}
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewSolanaCollector(cfg).LastScrape())
+
+	rec := httptest.NewRecorder()
+	StatusHandler(cfg, reg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var status map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatal("Error while decoding status :", err)
+	}
+	for field, expected := range map[string]interface{}{
+		"validator":    "test-val",
+		"voting":       true,
+		"delinquent":   false,
+		"commission":   float64(10),
+		"vote_credits": float64(4000),
+		"version":      "1.14.17",
+	} {
+		if status[field] != expected {
+			t.Errorf("Expected %s to be %v, got %v", field, expected, status[field])
+		}
+	}
+	if len(status) != 6 {
+		t.Errorf("Expected only the configured fields, got %v", status)
+	}
+	// keys and addresses of validator are never published
+	for _, sensitive := range []string{"valPubKey", "valVoteKey", "ip_address", "balance"} {
+		if strings.Contains(rec.Body.String(), sensitive) {
+			t.Errorf("Expected status page without %s, got %s", sensitive, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package exporter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// timestampGatherer is a gatherer which sets the time of gathering as the timestamp of every metric
+type timestampGatherer struct {
+	prometheus.Gatherer
+	now func() time.Time
+}
+
+// WithTimestamps wraps the given gatherer to include the scrape time as explicit timestamp of every metric
+// which doesn't carry one yet, for backfilling and push setups. Prometheus recommends against timestamps for
+// scraped metrics as it then can't mark stale series, so it is only enabled on request.
+func WithTimestamps(g prometheus.Gatherer) prometheus.Gatherer {
+	return &timestampGatherer{Gatherer: g, now: time.Now}
+}
+
+// Gather gathers the metrics of the wrapped gatherer and sets their timestamp
+func (tg *timestampGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := tg.Gatherer.Gather()
+	ts := tg.now().UnixNano() / int64(time.Millisecond)
+	for _, mf := range mfs {
+		for _, m := range mf.Metric {
+			if m.TimestampMs == nil {
+				m.TimestampMs = &ts
+			}
+		}
+	}
+	return mfs, err
+}
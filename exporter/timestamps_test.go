@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWithTimestamps(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	g := prometheus.NewGauge(prometheus.GaugeOpts{Name: "solana_test_gauge", Help: "Test gauge"})
+	g.Set(42)
+	reg.MustRegister(g)
+
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	if err := WriteMetricsFile(path, reg); err != nil {
+		t.Fatal("Error while writing metrics file :", err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "solana_test_gauge 42\n") {
+		t.Errorf("Expected no timestamp by default, got %q", data)
+	}
+
+	tg := WithTimestamps(reg).(*timestampGatherer)
+	tg.now = func() time.Time { return time.Unix(1700000000, 500*int64(time.Millisecond)) }
+	if err := WriteMetricsFile(path, tg); err != nil {
+		t.Fatal("Error while writing metrics file :", err)
+	}
+	data, err = ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "solana_test_gauge 42 1700000000500\n") {
+		t.Errorf("Expected the scrape time as timestamp, got %q", data)
+	}
+}
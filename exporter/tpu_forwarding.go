@@ -0,0 +1,77 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// tpuAddresses returns which of its tpu addresses the node with the given identity advertises in the cluster
+// nodes. ok is false if the node is not among them.
+func tpuAddresses(nodes *types.ClustrNode, pubKey string) (advertised map[string]bool, ok bool) {
+	for _, node := range nodes.Result {
+		if node.Pubkey != pubKey {
+			continue
+		}
+		return map[string]bool{
+			"tpu":               node.Tpu != "",
+			"tpu_quic":          node.TpuQuic != "",
+			"tpu_forwards":      node.TpuForwards != "",
+			"tpu_forwards_quic": node.TpuForwardsQuic != "",
+		}, true
+	}
+	return nil, false
+}
+
+// tpuForwardingDegraded returns why forwarding of transactions to the node is degraded judging by its advertised
+// tpu addresses, empty if it isn't. Clients send transactions over quic and other nodes forward the transactions
+// they can't process to the forwards address, so the node misses transactions without either of them.
+func tpuForwardingDegraded(advertised map[string]bool) string {
+	var missing []string
+	if !advertised["tpu_quic"] {
+		missing = append(missing, "quic tpu")
+	}
+	if !advertised["tpu_forwards"] && !advertised["tpu_forwards_quic"] {
+		missing = append(missing, "tpu forwards")
+	}
+	return strings.Join(missing, " and ")
+}
+
+// emitTPUForwarding exports which tpu addresses the node advertises in the cluster nodes and sends an alert when
+// it stops advertising the addresses transactions are sent and forwarded to, if enabled
+func (c *solanaCollector) emitTPUForwarding(ch chan<- prometheus.Metric) {
+	nodes, err := c.getCachedClusterNodes()
+	if err != nil {
+		log.Printf("Error while getting cluster nodes : %v", err)
+		return
+	}
+	advertised, ok := tpuAddresses(nodes, c.config.ValDetails.PubKey)
+	if !ok {
+		return
+	}
+	for address, on := range advertised {
+		v := float64(0)
+		if on {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.tpuAddressAdvertised, prometheus.GaugeValue, v, address)
+	}
+
+	if !strings.EqualFold(c.config.AlerterPreferences.TPUForwardingAlerts, "yes") {
+		return
+	}
+	missing := tpuForwardingDegraded(advertised)
+	if missing != "" && !c.tpuForwardingAlerted {
+		err := alerter.SendAlert(alerter.EventTPUForwarding, fmt.Sprintf("TPU Forwarding Alert : Your node doesn't advertise a %s address in gossip, it may miss transactions as leader",
+			missing), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending tpu forwarding alert: %v", err)
+		}
+	}
+	c.tpuForwardingAlerted = missing != ""
+}
@@ -0,0 +1,60 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestTPUForwardingDegraded(t *testing.T) {
+	for _, tc := range []struct {
+		advertised map[string]bool
+		missing    string
+	}{
+		{map[string]bool{"tpu": true, "tpu_quic": true, "tpu_forwards": true, "tpu_forwards_quic": true}, ""},
+		{map[string]bool{"tpu": true, "tpu_quic": true, "tpu_forwards_quic": true}, ""},
+		{map[string]bool{"tpu": true, "tpu_forwards": true}, "quic tpu"},
+		{map[string]bool{"tpu": true, "tpu_quic": true}, "tpu forwards"},
+		{map[string]bool{"tpu": true}, "quic tpu and tpu forwards"},
+	} {
+		if got := tpuForwardingDegraded(tc.advertised); got != tc.missing {
+			t.Errorf("Expected %q missing for %v, got %q", tc.missing, tc.advertised, got)
+		}
+	}
+}
+
+func TestCollectTPUForwarding(t *testing.T) {
+	results := testRPCResults()
+	// our node has no forwards address, the other one advertises all of them
+	results["getClusterNodes"] = `[
+		{"pubkey": "valPubKey", "gossip": "10.0.0.1:8001", "tpu": "10.0.0.1:8004", "tpuQuic": "10.0.0.1:8010", "rpc": null, "version": "1.14.17"},
+		{"pubkey": "otherPubKey", "gossip": "10.0.0.2:8001", "tpu": "10.0.0.2:8004", "tpuQuic": "10.0.0.2:8010", "tpuForwards": "10.0.0.2:8005", "tpuForwardsQuic": "10.0.0.2:8011", "rpc": null, "version": "1.14.17"}
+	]`
+	srv := newTestRPCServer(t, results)
+	cfg := newTestConfig(srv.URL)
+	cfg.AlerterPreferences.TPUForwardingAlerts = "yes"
+	c := NewSolanaCollector(cfg)
+
+	before := alertsSent(t, alerter.EventTPUForwarding, alerter.Warning)
+
+	for i := 0; i < 2; i++ {
+		advertised := make(map[string]float64)
+		for _, m := range collectMetrics(t, c)["solana_node_tpu_address_advertised"] {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "address" {
+					advertised[l.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+		for address, expected := range map[string]float64{"tpu": 1, "tpu_quic": 1, "tpu_forwards": 0, "tpu_forwards_quic": 0} {
+			if got, ok := advertised[address]; !ok || got != expected {
+				t.Errorf("Expected %s advertised to be %v, got %v", address, expected, got)
+			}
+		}
+	}
+
+	// the missing forwards address is alerted once
+	if got := alertsSent(t, alerter.EventTPUForwarding, alerter.Warning) - before; got != 1 {
+		t.Errorf("Expected 1 tpu forwarding alert, got %v", got)
+	}
+}
@@ -0,0 +1,87 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+// compareVersions compares the dotted numeric versions a and b e.g. 1.16.20, returning -1 if a is older, 0 if they
+// are equal and 1 if a is newer. Missing parts count as 0 and a suffix like -beta of a part is ignored.
+func compareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		va, vb := versionPart(pa, i), versionPart(pb, i)
+		if va < vb {
+			return -1
+		}
+		if va > vb {
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionPart returns the number of the i-th part of a version, 0 if it is missing or not a number
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	p := parts[i]
+	if j := strings.IndexFunc(p, func(r rune) bool { return r < '0' || r > '9' }); j >= 0 {
+		p = p[:j]
+	}
+	n, _ := strconv.Atoi(p)
+	return n
+}
+
+// upgradeAlertSeverity returns the severity of the alert of a node below the required version in the given epoch,
+// warning from warnEpochs before the required epoch and critical from it, empty before that
+func upgradeAlertSeverity(epoch, requiredBy, warnEpochs int64) string {
+	switch {
+	case epoch >= requiredBy:
+		return alerter.Critical
+	case epoch >= requiredBy-warnEpochs:
+		return alerter.Warning
+	}
+	return ""
+}
+
+// emitUpgradeReadiness exports whether the version of the node meets the configured required version and sends
+// an alert as the required epoch approaches while it doesn't, a warning first and critical once it is due
+func (c *solanaCollector) emitUpgradeReadiness(ch chan<- prometheus.Metric, version string) {
+	u := c.config.Upgrade
+	if u.RequiredVersion == "" {
+		return
+	}
+
+	ready := compareVersions(version, u.RequiredVersion) >= 0
+	value := float64(0)
+	if ready {
+		value = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.versionUpgradeReady, prometheus.GaugeValue, value)
+
+	epochInfo, err := c.getCachedEpochInfo()
+	if err != nil {
+		return
+	}
+	epoch := epochInfo.Result.Epoch
+	severity := ""
+	if !ready {
+		severity = upgradeAlertSeverity(epoch, u.RequiredByEpoch, u.WarnEpochs)
+	}
+	if severity != "" && severity != c.upgradeAlerted {
+		err := alerter.SendAlert(alerter.EventVersionUpgrade, fmt.Sprintf("Version Upgrade Alert : Your node runs version %s, version %s is required by epoch %d and the current epoch is %d",
+			version, u.RequiredVersion, u.RequiredByEpoch, epoch), severity, c.config)
+		if err != nil {
+			log.Printf("Error while sending version upgrade alert: %v", err)
+		}
+	}
+	c.upgradeAlerted = severity
+}
@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestCompareVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.16.20", "1.16.20", 0},
+		{"1.16.9", "1.16.20", -1},
+		{"1.17.0", "1.16.20", 1},
+		{"1.16", "1.16.0", 0},
+		{"1.16.20-beta", "1.16.20", 0},
+		{"2.0.1", "1.18.26", 1},
+	} {
+		if got := compareVersions(tc.a, tc.b); got != tc.expected {
+			t.Errorf("Expected compareVersions(%s, %s) = %d, got %d", tc.a, tc.b, tc.expected, got)
+		}
+	}
+}
+
+func TestCollectVersionUpgrade(t *testing.T) {
+	// the node runs 1.14.17 in epoch 100
+	for _, tc := range []struct {
+		required   string
+		requiredBy int64
+		expected   float64
+		warnings   float64
+		criticals  float64
+	}{
+		{"1.14.17", 101, 1, 0, 0},
+		// not ready with the deadline two epochs away is alerted once as a warning
+		{"1.16.20", 102, 0, 1, 0},
+		// not ready past the deadline is critical
+		{"1.16.20", 100, 0, 0, 1},
+		{"1.16.20", 110, 0, 0, 0},
+	} {
+		srv := newTestRPCServer(t, testRPCResults())
+		cfg := newTestConfig(srv.URL)
+		cfg.Upgrade.RequiredVersion = tc.required
+		cfg.Upgrade.RequiredByEpoch = tc.requiredBy
+		cfg.Upgrade.WarnEpochs = 2
+		c := NewSolanaCollector(cfg)
+
+		warnings := alertsSent(t, alerter.EventVersionUpgrade, alerter.Warning)
+		criticals := alertsSent(t, alerter.EventVersionUpgrade, alerter.Critical)
+
+		for i := 0; i < 2; i++ {
+			ms := collectMetrics(t, c)["solana_version_upgrade_ready"]
+			if len(ms) != 1 {
+				t.Fatalf("Expected 1 version upgrade ready metric for %s, got %d", tc.required, len(ms))
+			}
+			if got := ms[0].GetGauge().GetValue(); got != tc.expected {
+				t.Errorf("Expected version upgrade ready %v for %s, got %v", tc.expected, tc.required, got)
+			}
+		}
+
+		if got := alertsSent(t, alerter.EventVersionUpgrade, alerter.Warning) - warnings; got != tc.warnings {
+			t.Errorf("Expected %v version upgrade warnings for %s by %d, got %v", tc.warnings, tc.required, tc.requiredBy, got)
+		}
+		if got := alertsSent(t, alerter.EventVersionUpgrade, alerter.Critical) - criticals; got != tc.criticals {
+			t.Errorf("Expected %v version upgrade criticals for %s by %d, got %v", tc.criticals, tc.required, tc.requiredBy, got)
+		}
+	}
+}
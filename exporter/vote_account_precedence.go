@@ -0,0 +1,46 @@
+package exporter
+
+import (
+	"log"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// resolveOwnVoteAccount removes the vote account of the given identity from one of the lists when the same vote
+// account is both in the current and the delinquent vote accounts, which happens briefly during transitions, so
+// that every metric of the scrape sees it in one list only. Different vote accounts of the identity, e.g. the old
+// and the new one after a vote key rotation, are both kept. With the delinquent precedence (default) the delinquent entry is kept,
+// with last_vote the entry with the most recent vote is kept, the delinquent one on a tie.
+func resolveOwnVoteAccount(response types.GetVoteAccountsResponse, pubKey, precedence string) types.GetVoteAccountsResponse {
+	current, delinquent := -1, -1
+	for i, vote := range response.Result.Current {
+		if vote.NodePubkey != pubKey {
+			continue
+		}
+		for j, d := range response.Result.Delinquent {
+			if d.VotePubkey == vote.VotePubkey {
+				current, delinquent = i, j
+			}
+		}
+	}
+	if current < 0 || delinquent < 0 {
+		return response
+	}
+
+	keepCurrent := precedence == "last_vote" && response.Result.Current[current].LastVote > response.Result.Delinquent[delinquent].LastVote
+	if keepCurrent {
+		log.Printf("Vote account of validator is both current and delinquent, using the current one with the more recent vote")
+		response.Result.Delinquent = removeVoteAccount(response.Result.Delinquent, delinquent)
+	} else {
+		log.Printf("Vote account of validator is both current and delinquent, using the delinquent one")
+		response.Result.Current = removeVoteAccount(response.Result.Current, current)
+	}
+	return response
+}
+
+// removeVoteAccount returns a copy of the vote accounts without the i-th one
+func removeVoteAccount(accounts []types.VoteAccount, i int) []types.VoteAccount {
+	removed := make([]types.VoteAccount, 0, len(accounts)-1)
+	removed = append(removed, accounts[:i]...)
+	return append(removed, accounts[i+1:]...)
+}
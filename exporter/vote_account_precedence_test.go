@@ -0,0 +1,63 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// testBothVoteAccounts has the vote account of validator both in the current and delinquent vote accounts
+const testBothVoteAccounts = `{
+	"current": [
+		{"activatedStake": 5000000000000, "commission": 10, "epochCredits": [[100, 4000, 3000]], "epochVoteAccount": true, "lastVote": 1000, "nodePubkey": "valPubKey", "rootSlot": 968, "votePubkey": "valVoteKey"},
+		{"activatedStake": 1000000000000, "commission": 5, "epochCredits": [[100, 5000, 4000]], "epochVoteAccount": true, "lastVote": 1002, "nodePubkey": "otherPubKey", "rootSlot": 970, "votePubkey": "otherVoteKey"}
+	],
+	"delinquent": [
+		{"activatedStake": 5000000000000, "commission": 10, "epochCredits": [[100, 4000, 3000]], "epochVoteAccount": true, "lastVote": 900, "nodePubkey": "valPubKey", "rootSlot": 868, "votePubkey": "valVoteKey"}
+	]
+}`
+
+func TestCollectVoteAccountPrecedence(t *testing.T) {
+	for _, tc := range []struct {
+		precedence string
+		delinquent float64
+		lastVote   float64
+	}{
+		{"", 1, 900},
+		{"delinquent", 1, 900},
+		// the current entry voted more recently
+		{"last_vote", 0, 1000},
+	} {
+		results := testRPCResults()
+		results["getVoteAccounts"] = testBothVoteAccounts
+		srv := newTestRPCServer(t, results)
+		cfg := newTestConfig(srv.URL)
+		cfg.ValDetails.VoteAccountPrecedence = tc.precedence
+
+		metrics := collectMetrics(t, NewSolanaCollector(cfg))
+
+		delinquent := metrics["solana_validator_delinquent"]
+		if len(delinquent) != 1 || delinquent[0].GetGauge().GetValue() != tc.delinquent {
+			t.Errorf("Expected one delinquent value %v with precedence %q, got %v", tc.delinquent, tc.precedence, delinquent)
+		}
+		lastVote := metrics["solana_validator_last_vote"]
+		if len(lastVote) != 1 || lastVote[0].GetGauge().GetValue() != tc.lastVote {
+			t.Errorf("Expected one last vote %v with precedence %q, got %v", tc.lastVote, tc.precedence, lastVote)
+		}
+	}
+}
+
+func TestResolveOwnVoteAccountRotatedKey(t *testing.T) {
+	// after a vote key rotation the new vote account is current and the old one delinquent
+	var response types.GetVoteAccountsResponse
+	response.Result.Current = []types.VoteAccount{{NodePubkey: "valPubKey", VotePubkey: "newVoteKey", LastVote: 1000}}
+	response.Result.Delinquent = []types.VoteAccount{{NodePubkey: "valPubKey", VotePubkey: "valVoteKey", LastVote: 900}}
+
+	for _, precedence := range []string{"delinquent", "last_vote"} {
+		resolved := resolveOwnVoteAccount(response, "valPubKey", precedence)
+		if len(resolved.Result.Current) != 1 || len(resolved.Result.Delinquent) != 1 {
+			t.Errorf("Expected both vote accounts to be kept with precedence %q, got %d current and %d delinquent",
+				precedence, len(resolved.Result.Current), len(resolved.Result.Delinquent))
+		}
+	}
+}
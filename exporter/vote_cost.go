@@ -0,0 +1,74 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// minVoteCostSlots is the number of slots the identity balance has to be tracked in an epoch before the vote
+// cost is extrapolated to the whole epoch, so that a few samples at the start of an epoch don't skew it
+const minVoteCostSlots = 1000
+
+// voteCostTracker sums up the decreases of the identity account balance in an epoch. Vote transactions are
+// paid from the identity account, so the decreases are mostly vote fees. Increases e.g. deposits are ignored.
+type voteCostTracker struct {
+	epoch       int64
+	startSlot   int64
+	lastSlot    int64
+	lastBalance int64
+	spent       int64
+}
+
+// add records the balance in lamports of the identity account at the given slot, the tracker starts over
+// on a new epoch
+func (t *voteCostTracker) add(epoch, slot, lamports int64) {
+	if t.startSlot == 0 || epoch != t.epoch || slot < t.lastSlot {
+		*t = voteCostTracker{epoch: epoch, startSlot: slot, lastSlot: slot, lastBalance: lamports}
+		return
+	}
+	if lamports < t.lastBalance {
+		t.spent += t.lastBalance - lamports
+	}
+	t.lastSlot = slot
+	t.lastBalance = lamports
+}
+
+// perEpoch returns the lamports spent so far extrapolated to the given slots of the epoch.
+// ok is false until the balance has been tracked for minVoteCostSlots.
+func (t *voteCostTracker) perEpoch(slotsInEpoch int64) (lamports float64, ok bool) {
+	tracked := t.lastSlot - t.startSlot
+	if tracked < minVoteCostSlots || slotsInEpoch <= 0 {
+		return 0, false
+	}
+	return float64(t.spent) / float64(tracked) * float64(slotsInEpoch), true
+}
+
+// trackVoteCost records the identity balance at the given slot, exports the estimated vote cost of the epoch and
+// sends an alert when it reaches the configured threshold
+func (c *solanaCollector) trackVoteCost(cfg *config.Config, epoch, slotsInEpoch, slot, lamports int64) {
+	c.voteCost.add(epoch, slot, lamports)
+	cost, ok := c.voteCost.perEpoch(slotsInEpoch)
+	if !ok {
+		return
+	}
+	voteCostPerEpoch.Set(cost)
+
+	threshold := cfg.AlertingThresholds.VoteCostThresholdSOL
+	if threshold <= 0 {
+		return
+	}
+	thresholdLamports := float64(utils.SOLToLamports(threshold))
+	exceeded := cost >= thresholdLamports
+	if exceeded && !c.voteCostAlerted {
+		err := alerter.SendAlert(alerter.EventVoteCost, fmt.Sprintf("Vote Cost Alert : Estimated vote cost of your validator %s per epoch has reached the configured threshold %s",
+			alerter.FormatAmount(cost, cfg), alerter.FormatAmount(thresholdLamports, cfg)), alerter.Warning, cfg)
+		if err != nil {
+			log.Printf("Error while sending vote cost alert: %v", err)
+		}
+	}
+	c.voteCostAlerted = exceeded
+}
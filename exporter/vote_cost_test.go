@@ -0,0 +1,32 @@
+package exporter
+
+import "testing"
+
+func TestVoteCostTracker(t *testing.T) {
+	var tracker voteCostTracker
+
+	// 5000 lamports spent every 10 slots with a deposit in between
+	balance := int64(10000000000)
+	for slot := int64(1000); slot <= 3000; slot += 10 {
+		if slot == 2000 {
+			balance += 1000000000
+		}
+		tracker.add(100, slot, balance)
+		balance -= 5000
+	}
+
+	cost, ok := tracker.perEpoch(432000)
+	if !ok {
+		t.Fatal("Expected vote cost after tracking 2000 slots")
+	}
+	// 199 fees over 2000 slots, the fee paid along with the deposit is hidden by it
+	if cost != 214920000 {
+		t.Errorf("Expected vote cost of 214920000 lamports per epoch, got %v", cost)
+	}
+
+	// a new epoch starts over
+	tracker.add(101, 3010, balance)
+	if _, ok := tracker.perEpoch(432000); ok {
+		t.Error("Expected no vote cost at the start of a new epoch")
+	}
+}
@@ -0,0 +1,111 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+// creditsSample is the vote credits of validator in an epoch at a time
+type creditsSample struct {
+	epoch   int64
+	credits int64
+	at      time.Time
+}
+
+// emitVoteCreditsRate exports the vote credits earned since the previous scrape and their rate per minute,
+// and sends an alert when the rate drops below the configured floor. Neither is exported for the first
+// scrape of an epoch, as credits of different epochs are not comparable.
+func (c *solanaCollector) emitVoteCreditsRate(ch chan<- prometheus.Metric, epoch, credits int64, now time.Time) {
+	prev := c.lastCredits
+	cur := creditsSample{epoch: epoch, credits: credits, at: now}
+	rate, ok := creditsRate(prev, cur)
+	c.lastCredits = cur
+
+	c.emitPossibleRestart(ch, prev, cur)
+	if !ok {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.epochCreditsDelta, prometheus.GaugeValue, float64(cur.credits-prev.credits), "scrape")
+	ch <- prometheus.MustNewConstMetric(c.voteCreditsRate, prometheus.GaugeValue, rate)
+
+	floor := c.config.AlertingThresholds.VoteCreditsRateThreshold
+	if floor <= 0 {
+		return
+	}
+	below := rate < floor
+	if below && !c.creditsRateAlerted {
+		err := alerter.SendAlert(alerter.EventVoteCredits, fmt.Sprintf("Vote Credits Alert : Your validator is earning %.2f vote credits per minute, below the configured floor %.2f", rate, floor), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending vote credits rate alert: %v", err)
+		}
+	}
+	c.creditsRateAlerted = below
+}
+
+// emitPossibleRestart exports whether the vote credits of validator have dropped since the previous scrape
+// of the same epoch and sends an alert when they have, as it may have crashed or restarted
+func (c *solanaCollector) emitPossibleRestart(ch chan<- prometheus.Metric, prev, cur creditsSample) {
+	var value float64
+	if creditsDropped(prev, cur) {
+		value = 1
+		err := alerter.SendAlert(alerter.EventPossibleRestart, fmt.Sprintf("Possible Restart Alert : Vote credits of your validator dropped from %d to %d within epoch %d, it may have crashed or restarted", prev.credits, cur.credits, cur.epoch), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending possible restart alert: %v", err)
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(c.possibleRestart, prometheus.GaugeValue, value)
+}
+
+// creditsDropped reports whether the credits dropped between the given samples of the same epoch.
+// Credits of a new epoch are not compared, so an epoch rollover is not mistaken for a drop.
+func creditsDropped(prev, cur creditsSample) bool {
+	return !prev.at.IsZero() && prev.epoch == cur.epoch && cur.credits < prev.credits
+}
+
+// emitEpochCreditsDelta exports the difference of the credits earned by the validator so far in this epoch
+// and the credits it earned in the whole last epoch, negative while this epoch trails the last one
+func (c *solanaCollector) emitEpochCreditsDelta(ch chan<- prometheus.Metric, credits [][]int64, epoch int64) {
+	if delta, ok := epochCreditsDelta(credits, epoch); ok {
+		ch <- prometheus.MustNewConstMetric(c.epochCreditsDelta, prometheus.GaugeValue, float64(delta), "epoch")
+	}
+}
+
+// epochCreditsDelta returns the credits earned in the given epoch minus the credits earned in the epoch before it
+// from the epochCredits of a vote account, whose entries are [epoch, credits, previous credits] with credits
+// accumulated over all epochs. A new epoch starts from 0 earned credits. ok is false if there is no entry of the
+// last epoch to compare with.
+func epochCreditsDelta(credits [][]int64, epoch int64) (delta int64, ok bool) {
+	var this, last int64
+	var lastOK bool
+	for _, c := range credits {
+		if len(c) < 3 {
+			continue
+		}
+		switch c[0] {
+		case epoch:
+			this = c[1] - c[2]
+		case epoch - 1:
+			last = c[1] - c[2]
+			lastOK = true
+		}
+	}
+	if !lastOK {
+		return 0, false
+	}
+	return this - last, true
+}
+
+// creditsRate returns the vote credits earned per minute between the given samples.
+// ok is false when the samples are of different epochs or no time has passed between them.
+func creditsRate(prev, cur creditsSample) (rate float64, ok bool) {
+	if prev.at.IsZero() || prev.epoch != cur.epoch || !cur.at.After(prev.at) || cur.credits < prev.credits {
+		return 0, false
+	}
+	return float64(cur.credits-prev.credits) / cur.at.Sub(prev.at).Minutes(), true
+}
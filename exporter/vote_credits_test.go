@@ -0,0 +1,82 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+)
+
+func TestCreditsRate(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := creditsSample{epoch: 100, credits: 4000, at: start}
+
+	rate, ok := creditsRate(prev, creditsSample{epoch: 100, credits: 4300, at: start.Add(2 * time.Minute)})
+	if !ok || rate != 150 {
+		t.Errorf("Expected rate of 150 credits/min, got %v (ok %v)", rate, ok)
+	}
+
+	if _, ok := creditsRate(prev, creditsSample{epoch: 101, credits: 10, at: start.Add(time.Minute)}); ok {
+		t.Error("Expected no rate across epoch boundary")
+	}
+	if _, ok := creditsRate(creditsSample{}, prev); ok {
+		t.Error("Expected no rate without a previous sample")
+	}
+}
+
+func TestEpochCreditsDelta(t *testing.T) {
+	credits := [][]int64{{98, 3000, 1000}, {99, 5000, 3000}, {100, 6500, 5000}}
+
+	// 1500 credits so far in epoch 100 against 2000 in epoch 99
+	if delta, ok := epochCreditsDelta(credits, 100); !ok || delta != -500 {
+		t.Errorf("Expected epoch credits delta -500, got %d (ok %v)", delta, ok)
+	}
+
+	// a new epoch without an entry yet starts from 0 credits
+	if delta, ok := epochCreditsDelta(credits, 101); !ok || delta != -1500 {
+		t.Errorf("Expected epoch credits delta -1500 at the start of epoch, got %d (ok %v)", delta, ok)
+	}
+
+	if _, ok := epochCreditsDelta(credits[2:], 100); ok {
+		t.Error("Expected no delta without credits of last epoch")
+	}
+}
+
+func TestCollectPossibleRestart(t *testing.T) {
+	results := testRPCResults()
+	srv := newTestRPCServer(t, results)
+	c := NewSolanaCollector(newTestConfig(srv.URL))
+
+	before := alertsSent(t, alerter.EventPossibleRestart, alerter.Warning)
+
+	restart := func() float64 {
+		m := collectMetrics(t, c)["solana_validator_possible_restart"]
+		if len(m) != 1 {
+			t.Fatalf("Expected possible restart metric, got %v", m)
+		}
+		return m[0].GetGauge().GetValue()
+	}
+
+	if restart() != 0 {
+		t.Error("Expected no possible restart on first scrape")
+	}
+
+	// credits drop from 4000 to 3500 mid epoch
+	results["getVoteAccounts"] = strings.Replace(testVoteAccounts, "[[100, 4000, 3000]]", "[[100, 3500, 3000]]", 1)
+	if restart() != 1 {
+		t.Error("Expected possible restart after mid epoch credit drop")
+	}
+
+	// lower credits of the next epoch are a legitimate rollover
+	results["getEpochInfo"] = `{"absoluteSlot": 432010, "blockHeight": 900, "epoch": 101, "slotIndex": 10, "slotsInEpoch": 432000}`
+	results["getVoteAccounts"] = strings.Replace(testVoteAccounts, "[[100, 4000, 3000]]", "[[101, 10, 3500]]", 1)
+	c.cachedEpochTime = time.Time{}
+	if restart() != 0 {
+		t.Error("Expected no possible restart on epoch rollover")
+	}
+
+	if got := alertsSent(t, alerter.EventPossibleRestart, alerter.Warning) - before; got != 1 {
+		t.Errorf("Expected 1 possible restart alert, got %v", got)
+	}
+}
@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// activeVoteKey returns the vote account of the identity which is actively voting, i.e. which earned the most
+// credits in the epoch, or with the latest last vote among equal credits. ok is false if the identity has no vote account.
+func activeVoteKey(accounts []types.VoteAccount, identity string, epoch int64) (voteKey string, ok bool) {
+	var bestCredits int64
+	var bestLastVote int
+	for _, account := range accounts {
+		if account.NodePubkey != identity {
+			continue
+		}
+		var credits int64
+		for _, c := range account.EpochCredits {
+			if len(c) >= 3 && c[0] == epoch {
+				credits = c[1] - c[2]
+			}
+		}
+		if !ok || credits > bestCredits || (credits == bestCredits && account.LastVote > bestLastVote) {
+			voteKey, bestCredits, bestLastVote, ok = account.VotePubkey, credits, account.LastVote, true
+		}
+	}
+	return voteKey, ok
+}
+
+// emitVoteKeyActive exports whether the configured vote key is the vote account actively voting for the identity
+// of validator and sends an alert when it isn't, i.e. a wrong vote key is configured or the vote account was rotated
+func (c *solanaCollector) emitVoteKeyActive(ch chan<- prometheus.Metric, response types.GetVoteAccountsResponse) {
+	epochInfo, err := c.getCachedEpochInfo()
+	if err != nil {
+		return
+	}
+	active, ok := activeVoteKey(append(response.Result.Current, response.Result.Delinquent...), c.config.ValDetails.PubKey, epochInfo.Result.Epoch)
+	if !ok {
+		return
+	}
+
+	mismatch := active != c.config.ValDetails.VoteKey
+	value := float64(1)
+	if mismatch {
+		value = 0
+	}
+	ch <- prometheus.MustNewConstMetric(c.voteKeyActive, prometheus.GaugeValue, value)
+
+	if mismatch && !c.voteKeyMismatchAlerted {
+		err := alerter.SendAlert(alerter.EventVoteKeyMismatch, fmt.Sprintf("Vote Key Alert : Your validator votes on vote account %s instead of the configured vote key %s, check the vote_key in config",
+			active, c.config.ValDetails.VoteKey), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending vote key alert: %v", err)
+		}
+	}
+	c.voteKeyMismatchAlerted = mismatch
+}
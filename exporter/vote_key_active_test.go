@@ -0,0 +1,49 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestActiveVoteKey(t *testing.T) {
+	accounts := []types.VoteAccount{
+		{VotePubkey: "oldVoteKey", NodePubkey: "valPubKey", EpochCredits: [][]int64{{99, 3000, 2000}}, LastVote: 500},
+		{VotePubkey: "newVoteKey", NodePubkey: "valPubKey", EpochCredits: [][]int64{{99, 2000, 2000}, {100, 2400, 2000}}, LastVote: 1000},
+		{VotePubkey: "otherVoteKey", NodePubkey: "otherPubKey", EpochCredits: [][]int64{{100, 9000, 2000}}, LastVote: 1002},
+	}
+
+	if key, ok := activeVoteKey(accounts, "valPubKey", 100); !ok || key != "newVoteKey" {
+		t.Errorf("Expected newVoteKey to be active, got %q (%v)", key, ok)
+	}
+	// without credits in the epoch the latest last vote decides
+	if key, ok := activeVoteKey(accounts, "valPubKey", 101); !ok || key != "newVoteKey" {
+		t.Errorf("Expected newVoteKey to be active by last vote, got %q (%v)", key, ok)
+	}
+	if _, ok := activeVoteKey(accounts, "unknownPubKey", 100); ok {
+		t.Error("Expected no active vote key for an identity without vote accounts")
+	}
+}
+
+func TestCollectVoteKeyActive(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	cfg := newTestConfig(srv.URL)
+	c := NewSolanaCollector(cfg)
+	if ms := collectMetrics(t, c)["solana_vote_key_active"]; len(ms) != 1 || ms[0].GetGauge().GetValue() != 1 {
+		t.Fatalf("Expected configured vote key to be active, got %v", ms)
+	}
+
+	// the identity votes on another vote account than the configured one, which is alerted once
+	cfg.ValDetails.VoteKey = "staleVoteKey"
+	before := alertsSent(t, alerter.EventVoteKeyMismatch, alerter.Warning)
+	for i := 0; i < 2; i++ {
+		if ms := collectMetrics(t, c)["solana_vote_key_active"]; len(ms) != 1 || ms[0].GetGauge().GetValue() != 0 {
+			t.Errorf("Expected stale vote key to be inactive, got %v", ms)
+		}
+	}
+	if got := alertsSent(t, alerter.EventVoteKeyMismatch, alerter.Warning) - before; got != 1 {
+		t.Errorf("Expected 1 vote key alert, got %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// voteLatencyWindow is the number of recent scrapes over which the vote latency is averaged
+const voteLatencyWindow = 10
+
+// emitVoteLatency exports the average number of slots by which the votes of validator land behind
+// the cluster over the recent scrapes and sends an alert when it exceeds the configured threshold.
+func (c *solanaCollector) emitVoteLatency(ch chan<- prometheus.Metric, response types.GetVoteAccountsResponse) {
+	latency, ok := voteLatency(response, c.config.ValDetails.PubKey)
+	if !ok {
+		return
+	}
+
+	c.voteLatencies = append(c.voteLatencies, latency)
+	if len(c.voteLatencies) > voteLatencyWindow {
+		c.voteLatencies = c.voteLatencies[len(c.voteLatencies)-voteLatencyWindow:]
+	}
+	avg := averageVoteLatency(c.voteLatencies)
+
+	ch <- prometheus.MustNewConstMetric(c.voteLatency, prometheus.GaugeValue, avg)
+
+	threshold := c.config.AlertingThresholds.VoteLatencyThreshold
+	if threshold <= 0 {
+		return
+	}
+	exceeded := avg >= float64(threshold)
+	if exceeded && !c.voteLatencyAlerted {
+		err := alerter.SendAlert(alerter.EventVoteLatency, fmt.Sprintf("Vote Latency Alert : Average vote latency of your validator %.2f slots has exceeded the configured threshold %d", avg, threshold), alerter.Warning, c.config)
+		if err != nil {
+			log.Printf("Error while sending vote latency alert: %v", err)
+		}
+	}
+	c.voteLatencyAlerted = exceeded
+}
+
+// voteLatency returns the number of slots by which the last vote of the given validator is behind
+// the most recent vote of the cluster. ok is false if the validator has no vote account.
+func voteLatency(response types.GetVoteAccountsResponse, pubKey string) (latency int64, ok bool) {
+	var tip, lastVote int64
+	for _, account := range append(response.Result.Current, response.Result.Delinquent...) {
+		if int64(account.LastVote) > tip {
+			tip = int64(account.LastVote)
+		}
+		if account.NodePubkey == pubKey {
+			lastVote = int64(account.LastVote)
+			ok = true
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	return tip - lastVote, true
+}
+
+// averageVoteLatency returns the mean of the given vote latencies
+func averageVoteLatency(latencies []int64) float64 {
+	if len(latencies) == 0 {
+		return 0
+	}
+	var sum int64
+	for _, l := range latencies {
+		sum += l
+	}
+	return float64(sum) / float64(len(latencies))
+}
@@ -0,0 +1,55 @@
+package exporter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestVoteLatency(t *testing.T) {
+	var response types.GetVoteAccountsResponse
+	err := json.Unmarshal([]byte(`{"result": {
+		"current": [
+			{"lastVote": 1000, "nodePubkey": "valPubKey"},
+			{"lastVote": 1004, "nodePubkey": "otherPubKey"}
+		],
+		"delinquent": [
+			{"lastVote": 900, "nodePubkey": "delinquentPubKey"}
+		]
+	}}`), &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		pubKey   string
+		expected int64
+		ok       bool
+	}{
+		{pubKey: "valPubKey", expected: 4, ok: true},
+		{pubKey: "otherPubKey", expected: 0, ok: true},
+		{pubKey: "delinquentPubKey", expected: 104, ok: true},
+		{pubKey: "unknownPubKey", expected: 0, ok: false},
+	} {
+		latency, ok := voteLatency(response, tc.pubKey)
+		if latency != tc.expected || ok != tc.ok {
+			t.Errorf("%s: expected vote latency %d (%v), got %d (%v)", tc.pubKey, tc.expected, tc.ok, latency, ok)
+		}
+	}
+
+	if avg := averageVoteLatency([]int64{2, 4, 9}); avg != 5 {
+		t.Errorf("Expected average vote latency 5, got %v", avg)
+	}
+}
+
+func TestCollectVoteLatency(t *testing.T) {
+	srv := newTestRPCServer(t, testRPCResults())
+
+	metrics := collectMetrics(t, NewSolanaCollector(newTestConfig(srv.URL)))
+
+	latency := metrics["solana_validator_vote_latency_slots"]
+	if len(latency) != 1 || latency[0].GetGauge().GetValue() != 2 {
+		t.Errorf("Expected vote latency of 2 slots, got %v", latency)
+	}
+}
@@ -3,15 +3,19 @@ module github.com/Chainflow/solana-mission-control
 go 1.15
 
 require (
+	github.com/getsentry/sentry-go v0.11.0
 	github.com/go-playground/universal-translator v0.17.0 // indirect
 	github.com/go-telegram-bot-api/telegram-bot-api v4.6.4+incompatible
 	github.com/influxdata/influxdb1-client v0.0.0-20200827194710-b269163b24ab
 	github.com/leodido/go-urn v1.2.1 // indirect
 	github.com/prometheus/client_golang v0.9.3
-	github.com/sendgrid/rest v2.6.2+incompatible // indirect
+	github.com/prometheus/client_model v0.0.0-20190129233127-fd36f4220a90
+	github.com/sendgrid/rest v2.6.2+incompatible
 	github.com/sendgrid/sendgrid-go v3.8.0+incompatible
 	github.com/sirupsen/logrus v1.7.0
 	github.com/spf13/viper v1.7.1
 	github.com/technoweenie/multipartstreamer v1.0.1 // indirect
+	golang.org/x/sync v0.0.0-20201207232520-09787c993a3a
 	gopkg.in/go-playground/validator.v9 v9.31.0
+	gopkg.in/natefinch/lumberjack.v2 v2.0.0
 )
@@ -1,11 +1,11 @@
 package main
 
 import (
-	"fmt"
+	"flag"
 	"log"
-	"math"
-	"net/http"
-	"strings"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,18 +15,69 @@ import (
 	"github.com/Chainflow/solana-mission-control/config"
 	"github.com/Chainflow/solana-mission-control/exporter"
 	"github.com/Chainflow/solana-mission-control/monitor"
-	"github.com/Chainflow/solana-mission-control/utils"
+	"github.com/Chainflow/solana-mission-control/querier"
 )
 
 func main() {
+	printDefaultConfig := flag.Bool("print-default-config", false, "print a commented default config.toml with every config field and exit")
+	dumpJSON := flag.Bool("dump-json", false, "collect the metrics once, print them as json and exit")
+	flag.Parse()
+
+	if *printDefaultConfig {
+		if err := config.WriteDefaultConfig(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	cfg, err := config.ReadFromFile() // Read config file
 	if err != nil {
 		log.Fatal(err)
 	}
+	exporter.ConfigLoaded(time.Now())
+
+	if err := monitor.ConfigureLogging(cfg); err != nil {
+		log.Fatal(err)
+	}
+	if err := monitor.ConfigureProxy(cfg); err != nil {
+		log.Fatal(err)
+	}
+	if err := monitor.ConfigureRPCPolicies(cfg); err != nil {
+		log.Fatal(err)
+	}
+	if err := monitor.ConfigureSentry(cfg, nil); err != nil {
+		log.Fatal(err)
+	}
+	if cfg.TestMode.Enabled {
+		if err := monitor.ConfigureTestMode(cfg); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *dumpJSON {
+		// only the collector is registered, the metrics of WatchSlots are not collected on demand
+		r := prometheus.NewRegistry()
+		r.MustRegister(exporter.NewSolanaCollector(cfg))
+		if err := exporter.DumpJSON(os.Stdout, r); err != nil {
+			log.Printf("Some metrics could not be collected : %v", err)
+		}
+		return
+	}
+
+	if len(querier.PrometheusAddresses(cfg)) > 0 {
+		if err := querier.CheckPrometheus(cfg); err != nil {
+			log.Printf("Prometheus is not reachable, regular status alerts and telegram commands which query it won't work : %v", err)
+		}
+	}
 
 	collector := exporter.NewSolanaCollector(cfg)
 
 	go collector.WatchSlots(cfg)
+	go collector.WatchPorts(cfg)
+
+	if cfg.QuietHours.Start != "" && cfg.QuietHours.End != "" {
+		go alerter.WatchQuietHours(cfg)
+	}
 
 	// Calling command based alerting
 	go func() {
@@ -36,50 +87,69 @@ func main() {
 		}
 	}()
 
+	if !cfg.IsRPCNode() {
+		go func() {
+			for {
+				monitor.SkipRateAlerts(cfg)
+				time.Sleep(60 * time.Second)
+			}
+		}()
+	}
+
+	monitor.SendStartupAlert(cfg)
+
+	go monitor.WatchTelemetry(cfg)
+	go monitor.WatchAlertRules(cfg)
+
+	// send the shutdown alert on a graceful exit
 	go func() {
-		for {
-			monitor.SkipRateAlerts(cfg)
-			time.Sleep(60 * time.Second)
-		}
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		s := <-sig
+		log.Printf("Received %s, shutting down", s)
+		monitor.SendShutdownAlert(cfg, s.String())
+		monitor.FlushSentry(2 * time.Second)
+		os.Exit(0)
 	}()
 
-	if strings.EqualFold(cfg.AlerterPreferences.StartupAlerts, "yes") {
-		currEpoch := monitor.GetEpochDetails(cfg)
-
-		activatedStake := float64(-1)
-		voteAccs, err := monitor.GetVoteAccounts(cfg, utils.Network)
-		if err != nil {
-			log.Printf("Error while getting vote accounts: %v", err)
-		} else {
-			for _, vote := range voteAccs.Result.Current {
-				if vote.NodePubkey == cfg.ValDetails.PubKey {
-					activatedStake = float64(vote.ActivatedStake) / math.Pow(10, 9)
-					break
-				}
-			}
-		}
+	// the collector attaches the static labels itself, the other metrics get them through the registerer
+	reg := prometheus.WrapRegistererWith(prometheus.Labels(cfg.MetricLabels()), prometheus.DefaultRegisterer)
+	exporter.RegisterMetrics(reg)
+	alerter.RegisterMetrics(reg)
+	reg.MustRegister(alerter.NewSuppressionsCollector(cfg))
+	monitor.RegisterMetrics(reg)
+	exporter.RegisterCollectors(reg)
+	if cfg.Prometheus.LegacyMetricNames {
+		exporter.RegisterLegacyMetrics(reg)
+	}
 
-		// send alert
-		msg := fmt.Sprintf("Solana Mission Control started up. Current Epoch Info:\n%s\nActivated Stake: %.4f", currEpoch, activatedStake)
-		err = alerter.SendTelegramAlert(msg, cfg)
-		if err != nil {
-			log.Printf("Error while sending startup alert to telegram: %v", err)
-		}
-		// send email alert
-		err = alerter.SendEmailAlert(msg, cfg)
-		if err != nil {
-			log.Printf("Error while sending startup alert to email: %v", err)
-		}
-		// send slack alert
-		err = alerter.SendSlackAlert(msg, cfg)
-		if err != nil {
-			log.Printf("Error while sending startup alert to slack: %v", err)
-		}
+	// prometheus scrapes the collector, the status page, statsd and the metrics file reuse its last scrape
+	scrapes, lastScrapes := prometheus.NewRegistry(), prometheus.NewRegistry()
+	scrapes.MustRegister(collector)
+	lastScrapes.MustRegister(collector.LastScrape())
+
+	var gatherer prometheus.Gatherer = prometheus.Gatherers{prometheus.DefaultGatherer, scrapes}
+	var lastGatherer prometheus.Gatherer = prometheus.Gatherers{prometheus.DefaultGatherer, lastScrapes}
+	fileGatherer := lastGatherer
+	if cfg.Prometheus.MetricTimestamps {
+		gatherer = exporter.WithTimestamps(gatherer)
+		fileGatherer = exporter.WithTimestamps(fileGatherer)
+	}
+
+	if cfg.Prometheus.StatsDAddress != "" {
+		go exporter.WatchStatsD(cfg, lastGatherer)
+	}
+	if cfg.Prometheus.MetricsFilePath != "" {
+		go exporter.WatchMetricsFile(cfg, fileGatherer)
+	}
+	if cfg.Prometheus.ListenAddress == "" && (cfg.Prometheus.MetricsFilePath != "" || cfg.Prometheus.StatsDAddress != "") {
+		select {} // metrics are only written to the file or sent to statsd
 	}
 
-	prometheus.MustRegister(collector)
-	http.Handle("/metrics", promhttp.Handler()) // exported metrics can be seen in /metrics
-	err = http.ListenAndServe(fmt.Sprintf("%s", cfg.Prometheus.ListenAddress), nil)
+	// exported metrics can be seen in /metrics
+	metrics := promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	srv := exporter.NewMetricsServer(cfg, metrics, lastGatherer)
+	err = exporter.ServeMetrics(cfg, srv)
 	if err != nil {
 		log.Printf("Error while listening on server : %v", err)
 	}
@@ -0,0 +1,105 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/querier"
+)
+
+// defaultAlertRulesInterval is the interval at which the custom alert rules are evaluated if not configured
+const defaultAlertRulesInterval = time.Minute
+
+// alertRules evaluates the configured custom alert rules and remembers which of them fire, so that the alert of
+// a rule is sent once when it starts firing and once more when it resolves
+type alertRules struct {
+	firing map[string]bool
+}
+
+// WatchAlertRules evaluates the configured custom alert rules against prometheus at the configured interval,
+// it returns right away when no rule is configured
+func WatchAlertRules(cfg *config.Config) {
+	if len(cfg.AlertRules.Rules) == 0 {
+		return
+	}
+
+	interval := defaultAlertRulesInterval
+	if cfg.AlertRules.Interval != "" {
+		d, err := time.ParseDuration(cfg.AlertRules.Interval)
+		if err != nil || d <= 0 {
+			log.Printf("Invalid alert rules interval %q, using default %s", cfg.AlertRules.Interval, defaultAlertRulesInterval)
+		} else {
+			interval = d
+		}
+	}
+
+	var rules alertRules
+	for {
+		rules.evaluate(cfg)
+		time.Sleep(interval)
+	}
+}
+
+// ruleFires reports whether the samples of a rule query fire its alert, i.e. one of them is not 0. A comparison
+// without bool returns no sample when it is false, one with bool returns 0.
+func ruleFires(values []float64) bool {
+	for _, v := range values {
+		if v != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate queries every configured rule and sends an alert for the rules which started firing and the rules
+// which resolved, which are returned. A rule whose query fails keeps its state.
+func (r *alertRules) evaluate(cfg *config.Config) (fired, resolved []string) {
+	if r.firing == nil {
+		r.firing = make(map[string]bool)
+	}
+
+	names := make([]string, 0, len(cfg.AlertRules.Rules))
+	for name := range cfg.AlertRules.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		query := cfg.AlertRules.Rules[name]
+		values, err := querier.QueryValues(cfg, query)
+		if err != nil {
+			log.Printf("Error while evaluating alert rule %s : %v", name, err)
+			continue
+		}
+
+		firing := ruleFires(values)
+		if firing == r.firing[name] {
+			continue
+		}
+		r.firing[name] = firing
+
+		severity, msg := ruleSeverity(cfg, name), fmt.Sprintf("Alert Rule %s : %s is firing", name, query)
+		if firing {
+			fired = append(fired, name)
+		} else {
+			severity, msg = alerter.Info, fmt.Sprintf("Alert Rule %s : %s resolved", name, query)
+			resolved = append(resolved, name)
+		}
+		if err := alerter.SendAlert(alerter.EventAlertRule, msg, severity, cfg); err != nil {
+			log.Printf("Error while sending alert of rule %s: %v", name, err)
+		}
+	}
+	return fired, resolved
+}
+
+// ruleSeverity returns the configured severity of the alert of the given rule, warning if not set
+func ruleSeverity(cfg *config.Config, name string) string {
+	if severity, ok := cfg.AlertRules.Severities[name]; ok {
+		return severity
+	}
+	return alerter.Warning
+}
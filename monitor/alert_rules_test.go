@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestAlertRules(t *testing.T) {
+	skipRate := "12"
+	prom := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "solana_val_skip_rate > 10":
+			if skipRate == "" {
+				w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+				return
+			}
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"` + skipRate + `"]}]}}`))
+		case "solana_node_health > bool 0":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0"]}]}}`))
+		default:
+			http.Error(w, "bad query", http.StatusBadRequest)
+		}
+	}))
+	defer prom.Close()
+
+	cfg := &config.Config{Prometheus: config.Prometheus{PrometheusAddress: prom.URL}}
+	cfg.AlertRules.Rules = map[string]string{
+		"high_skip": "solana_val_skip_rate > 10",
+		"node_down": "solana_node_health > bool 0",
+		"invalid":   "solana_val_skip_rate >",
+	}
+	cfg.AlertRules.Severities = map[string]string{"high_skip": "critical"}
+
+	var rules alertRules
+	expect := func(step string, fired, resolved []string) {
+		t.Helper()
+		f, r := rules.evaluate(cfg)
+		if !reflect.DeepEqual(f, fired) || !reflect.DeepEqual(r, resolved) {
+			t.Errorf("Expected fired %v and resolved %v %s, got %v and %v", fired, resolved, step, f, r)
+		}
+	}
+
+	expect("when the skip rate trips the rule", []string{"high_skip"}, nil)
+	expect("while the rule keeps firing", nil, nil)
+
+	skipRate = ""
+	expect("when the rule returns no sample", nil, []string{"high_skip"})
+	expect("while the rule keeps resolved", nil, nil)
+
+	if got := ruleSeverity(cfg, "high_skip"); got != "critical" {
+		t.Errorf("Expected configured severity critical, got %s", got)
+	}
+	if got := ruleSeverity(cfg, "node_down"); got != "warning" {
+		t.Errorf("Expected default severity warning, got %s", got)
+	}
+}
@@ -13,16 +13,18 @@ import (
 	"github.com/Chainflow/solana-mission-control/config"
 	// "github.com/Chainflow/solana-mission-control/querier"
 	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
 )
 
 // GetIdentityBalance returns the balance of the identity account
 func GetIdentityBalance(cfg *config.Config) (types.Balance, error) {
-	log.Println("Getting identity account Balance...")
+	debugf("Getting identity account Balance...")
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
 		Body: types.Payload{Jsonrpc: "2.0", Method: "getBalance", ID: 1, Params: []interface{}{
 			cfg.ValDetails.PubKey, // should be base58 encoded to query data
+			commitment(cfg),
 		}},
 	}
 
@@ -49,12 +51,13 @@ func GetIdentityBalance(cfg *config.Config) (types.Balance, error) {
 
 // GetVoteAccBalance returns the balance of the vote account
 func GetVoteAccBalance(cfg *config.Config) (types.Balance, error) {
-	log.Println("Getting vote Aaccount Balance...")
+	debugf("Getting vote Aaccount Balance...")
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
 		Body: types.Payload{Jsonrpc: "2.0", Method: "getBalance", ID: 1, Params: []interface{}{
 			cfg.ValDetails.VoteKey, // should be base58 encoded to query data
+			commitment(cfg),
 		}},
 	}
 
@@ -91,22 +94,11 @@ func SendBalanceChangeAlert(currentBal int64, cfg *config.Config) error {
 	previous := prevBal + "SOL"
 
 	if strings.EqualFold(cfg.AlerterPreferences.AccountBalanceChangeAlerts, "yes") {
-		if cBal < cfg.AlertingThresholds.BalanaceChangeThreshold {
-			err := alerter.SendTelegramAlert(fmt.Sprintf("Account Balance Alert: Your account balance has dropped below configured threshold, current balance is : %s", current), cfg)
+		// compared in lamports, the rounded balance in SOL would hide balances just below the threshold
+		if currentBal < utils.SOLToLamports(cfg.AlertingThresholds.BalanceChangeThresholdSOL) {
+			err := alerter.SendAlert(alerter.EventBalance, fmt.Sprintf("Account Balance Alert: Your account balance has dropped below configured threshold, current balance is : %s", alerter.FormatAmount(float64(currentBal), cfg)), alerter.Critical, cfg)
 			if err != nil {
-				log.Printf("Error while sending account balance change alert to telegram : %v", err)
-				return err
-			}
-
-			err = alerter.SendEmailAlert(fmt.Sprintf("Account Balance Alert: Your account balance has dropped below configured threshold, current balance is : %s", current), cfg)
-			if err != nil {
-				log.Printf("Error while sending account balance change alert to email: %v", err)
-				return err
-			}
-
-			err = alerter.SendSlackAlert(fmt.Sprintf("Account Balance Alert: Your account balance has dropped below configured threshold, current balance is : %s", current), cfg)
-			if err != nil {
-				log.Printf("Error while sending account balance change alert to slack: %v", err)
+				log.Printf("Error while sending account balance change alert : %v", err)
 				return err
 			}
 		}
@@ -123,45 +115,15 @@ func SendBalanceChangeAlert(currentBal int64, cfg *config.Config) error {
 		if strings.EqualFold(cfg.AlerterPreferences.DelegationAlerts, "yes") {
 			diff := cBal - pBal
 			if diff > 50 && diff < 100 { // check and change the condition
-				// Alert to telegram
-				err = alerter.SendTelegramAlert(fmt.Sprintf("Delegation Alert: Your account balance has changed form %s to %s", previous, current), cfg)
-				if err != nil {
-					log.Printf("Error while sending delegation alert to telegram : %v", err)
-					return err
-				}
-
-				// Alert to email
-				err = alerter.SendEmailAlert(fmt.Sprintf("Delegation Alert: Your account balance has changed form %s to %s", previous, current), cfg)
+				err = alerter.SendAlert(alerter.EventDelegation, fmt.Sprintf("Delegation Alert: Your account balance has changed form %s to %s", previous, current), alerter.Info, cfg)
 				if err != nil {
-					log.Printf("Error while sending delegation alert to email : %v", err)
-					return err
-				}
-
-				// Alert to slack
-				err = alerter.SendSlackAlert(fmt.Sprintf("Delegation Alert: Your account balance has changed form %s to %s", previous, current), cfg)
-				if err != nil {
-					log.Printf("Error while sending delegation alert to slack : %v", err)
+					log.Printf("Error while sending delegation alert : %v", err)
 					return err
 				}
 			} else if diff < -50 { // check and change the condition
-				// Alert to telegram
-				err = alerter.SendTelegramAlert(fmt.Sprintf("Undelegation Alert: Your account balance has changed form %s to %s", previous, current), cfg)
-				if err != nil {
-					log.Printf("Error while sending undelegation alert to telegram : %v", err)
-					return err
-				}
-
-				// Alert to email
-				err = alerter.SendEmailAlert(fmt.Sprintf("Undelegation Alert: Your account balance has changed form %s to %s", previous, current), cfg)
-				if err != nil {
-					log.Printf("Error while sending undelegation alert to email : %v", err)
-					return err
-				}
-
-				// Alert to slack
-				err = alerter.SendSlackAlert(fmt.Sprintf("Undelegation Alert: Your account balance has changed form %s to %s", previous, current), cfg)
+				err = alerter.SendAlert(alerter.EventUndelegation, fmt.Sprintf("Undelegation Alert: Your account balance has changed form %s to %s", previous, current), alerter.Warning, cfg)
 				if err != nil {
-					log.Printf("Error while sending undelegation alert to slack : %v", err)
+					log.Printf("Error while sending undelegation alert : %v", err)
 					return err
 				}
 			}
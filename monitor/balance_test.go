@@ -1,6 +1,9 @@
 package monitor_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/Chainflow/solana-mission-control/config"
@@ -23,3 +26,40 @@ func TestGetBalance(t *testing.T) {
 		t.Log("Got Account Balance: ", res.Result)
 	}
 }
+
+func TestSendBalanceChangeAlertThreshold(t *testing.T) {
+	var alerts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		alerts = append(alerts, payload["text"])
+	}))
+	defer slack.Close()
+
+	cfg := &config.Config{
+		EnableAlerts:       config.EnableAlerts{EnableSlackAlerts: true},
+		Slack:              config.Slack{WebhookURL: slack.URL},
+		AlerterPreferences: config.AlerterPreferences{AccountBalanceChangeAlerts: "yes"},
+		AlertingThresholds: config.AlertingThreshold{BalanceChangeThresholdSOL: 10},
+	}
+
+	for _, tc := range []struct {
+		lamports int64
+		alert    bool
+	}{
+		// balances in lamports are far above a threshold in SOL if compared without conversion
+		{5000000000, true},
+		// 9.99996 SOL rounds to 10.0000 SOL but is still below the threshold
+		{9999960000, true},
+		{10000000000, false},
+		{20000000000, false},
+	} {
+		alerts = nil
+		if err := monitor.SendBalanceChangeAlert(tc.lamports, cfg); err != nil {
+			t.Fatal("Error while sending balance alert :", err)
+		}
+		if got := len(alerts) == 1; got != tc.alert {
+			t.Errorf("Expected alert %v for balance of %d lamports below 10 SOL, got %v", tc.alert, tc.lamports, alerts)
+		}
+	}
+}
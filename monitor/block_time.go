@@ -7,12 +7,16 @@ import (
 
 	"github.com/Chainflow/solana-mission-control/config"
 	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
 )
 
 // GetBlockTime returns the estimated production time of a confirmed block
 func GetBlockTime(slot int64, cfg *config.Config) (types.BlockTime, error) {
-	log.Println("Getting block time...")
+	debugf("Getting block time...")
 	var result types.BlockTime
+	if err := checkSlotAvailable(cfg, utils.Validator, slot); err != nil {
+		return result, err
+	}
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
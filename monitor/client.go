@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
 	"time"
@@ -12,6 +13,17 @@ import (
 	"github.com/Chainflow/solana-mission-control/types"
 )
 
+// defaultCommitment is the commitment level used when none is configured
+const defaultCommitment = "confirmed"
+
+// commitment returns the configured commitment level of rpc queries
+func commitment(cfg *config.Config) types.Commitment {
+	if cfg.Endpoints.Commitment == "" {
+		return types.Commitment{Commitemnt: defaultCommitment}
+	}
+	return types.Commitment{Commitemnt: cfg.Endpoints.Commitment}
+}
+
 func addQueryParameters(req *http.Request, queryParams types.QueryParams) {
 	params := url.Values{}
 	for key, value := range queryParams {
@@ -74,23 +86,41 @@ func makeResponse(res *http.Response) (*types.PingResp, error) {
 	return response, nil
 }
 
-// HitHTTPTarget to hit the target and get response
+// HitHTTPTarget to hit the target and get response. Failed requests are retried as configured
+// for the rpc method, see ConfigureRPCPolicies
 func HitHTTPTarget(ops types.HTTPOptions) (*types.PingResp, error) {
-	req, err := newHTTPRequest(ops)
-	if err != nil {
-		return nil, err
+	policy := policyFor(ops.Body.Method)
+
+	var err error
+	for attempt := 0; attempt <= policy.retries; attempt++ {
+		if attempt > 0 {
+			log.Printf("Retrying %s after error: %v", ops.Body.Method, err)
+		}
+		var res *types.PingResp
+		res, err = hitHTTPTarget(ops, policy.timeout)
+		if err == nil {
+			recordEndpointHealth(ops.Endpoint, res.StatusCode < http.StatusInternalServerError)
+			checkRPCResponse(ops, res)
+			return res, nil
+		}
 	}
+	recordEndpointHealth(ops.Endpoint, false)
+	reportRPCError(ops, "request", err)
+	return nil, err
+}
 
-	httpcli := http.Client{Timeout: time.Duration(10 * time.Second)}
-	resp, err := httpcli.Do(req)
+// hitHTTPTarget sends a single request bounded by the given timeout
+func hitHTTPTarget(ops types.HTTPOptions, timeout time.Duration) (*types.PingResp, error) {
+	req, err := newHTTPRequest(ops)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := makeResponse(resp)
+	httpcli := http.Client{Timeout: timeout, Transport: transport}
+	resp, err := httpcli.Do(req)
 	if err != nil {
 		return nil, err
 	}
-
-	return res, nil
+	recordRateLimit(ops.Endpoint, resp.Header)
+	return makeResponse(resp)
 }
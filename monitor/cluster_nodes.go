@@ -11,7 +11,7 @@ import (
 
 // GetClusterNodes returns information about all the nodes participating in the cluster
 func GetClusterNodes(cfg *config.Config) (types.ClustrNode, error) {
-	log.Println("Getting Cluster Nodes...")
+	debugf("Getting Cluster Nodes...")
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
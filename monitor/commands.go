@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 
+	"github.com/Chainflow/solana-mission-control/alerter"
 	"github.com/Chainflow/solana-mission-control/config"
 	"github.com/Chainflow/solana-mission-control/querier"
 	"github.com/Chainflow/solana-mission-control/types"
@@ -43,6 +45,11 @@ func TelegramAlerting(cfg *config.Config) {
 			continue
 		}
 
+		if !IsConfiguredChat(update, cfg) {
+			log.Printf("Ignoring command from unknown chat %d", update.Message.Chat.ID)
+			continue
+		}
+
 		if update.Message.Text == "/status" {
 			msgToSend = GetStatus(cfg)
 		} else if update.Message.Text == "/node" {
@@ -59,6 +66,8 @@ func TelegramAlerting(cfg *config.Config) {
 			msgToSend = GetBlockProduction(cfg)
 		} else if update.Message.Text == "/rpc_status" {
 			msgToSend = GetEndPointStatus(cfg)
+		} else if strings.HasPrefix(update.Message.Text, "/snooze") {
+			msgToSend = SnoozeAlerts(update.Message.Text)
 		} else if update.Message.Text == "/stop" {
 			msgToSend = Stop()
 			if msgToSend != "" {
@@ -98,7 +107,7 @@ func GetHelp() string {
 		"and network block height\n /node - return status of caught-up\n" +
 		" /balance - returns the current balance of your account \n /epoch - returns current epoch of " +
 		"network and validator\n /vote_credits - returns vote credits of current and" +
-		"previous epochs \n /skip_rate - returns the skip rate of validator and network \n /block_production - returns the recent block production details \n /rpc_status - returns the status of validator rpc and network rpc i.e., running or not\n /stop - which panics the running code and also alerts will be stopped\n /list - list out the available commands"
+		"previous epochs \n /skip_rate - returns the skip rate of validator and network \n /block_production - returns the recent block production details \n /rpc_status - returns the status of validator rpc and network rpc i.e., running or not\n /snooze <minutes> - suppresses all alerts for the given minutes, 0 resumes alerting\n /stop - which panics the running code and also alerts will be stopped\n /list - list out the available commands"
 
 	return msg
 }
@@ -126,20 +135,51 @@ func GetStatus(cfg *config.Config) string {
 	}
 	msg = msg + fmt.Sprintf("Network  block height : %d\n", networkHeight.Result.BlockHeight)
 
+	if until := alerter.SnoozedUntil(); !until.IsZero() {
+		msg = msg + fmt.Sprintf("Alerts are snoozed until %s\n", until.UTC().Format(time.RFC1123))
+	}
+
 	return msg
 }
 
+// IsConfiguredChat reports whether the update comes from the configured telegram chat,
+// commands from any other chat are ignored
+func IsConfiguredChat(update tgbotapi.Update, cfg *config.Config) bool {
+	return update.Message != nil && update.Message.Chat != nil && update.Message.Chat.ID == cfg.Telegram.ChatID
+}
+
+// SnoozeAlerts suppresses all alerts for the minutes given in /snooze <minutes> and returns the reply message
+func SnoozeAlerts(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) != 2 {
+		return "Usage : /snooze <minutes>"
+	}
+
+	minutes, err := strconv.Atoi(fields[1])
+	if err != nil || minutes < 0 {
+		return "Usage : /snooze <minutes>, minutes should be a positive number"
+	}
+
+	alerter.Snooze(time.Duration(minutes) * time.Minute)
+	if minutes == 0 {
+		return "Alerts are resumed"
+	}
+	return fmt.Sprintf("Alerts are snoozed for %d minutes", minutes)
+}
+
 // NodeStatus returns the node health wetaher it is up or down by giving /node
 func NodeStatus(cfg *config.Config) string {
 	var status string
 
-	nodeHealth, err := GetNodeHealth(cfg) // Get solana node health
+	nodeHealth, slotsBehind, err := GetNodeHealth(cfg) // Get solana node health
 	if err != nil {
 		log.Printf("Error while getting node health : %v", err)
 	}
 
 	if nodeHealth == 1 {
 		status = fmt.Sprintf("- Your Solana validator node is %s \n", "UP")
+	} else if slotsBehind > 0 {
+		status = fmt.Sprintf("- Your Solana validator node is %d slots BEHIND \n", slotsBehind)
 	} else {
 		status = fmt.Sprintf("- Your Solana validator node is %s \n", "DOWN")
 	}
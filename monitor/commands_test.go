@@ -0,0 +1,38 @@
+package monitor_test
+
+import (
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+func TestSnoozeAlerts(t *testing.T) {
+	cfg := &config.Config{Telegram: config.Telegram{ChatID: 2121888205}}
+	defer alerter.Snooze(0)
+
+	update := tgbotapi.Update{Message: &tgbotapi.Message{Text: "/snooze 30", Chat: &tgbotapi.Chat{ID: 2121888205}}}
+	if !monitor.IsConfiguredChat(update, cfg) {
+		t.Fatal("Expected update to come from the configured chat")
+	}
+
+	monitor.SnoozeAlerts(update.Message.Text)
+	until := alerter.SnoozedUntil()
+	if d := time.Until(until); d < 29*time.Minute || d > 30*time.Minute {
+		t.Errorf("Expected alerts to be snoozed for 30 minutes, snoozed until %v", until)
+	}
+
+	monitor.SnoozeAlerts("/snooze abc")
+	if !alerter.SnoozedUntil().Equal(until) {
+		t.Error("Expected invalid snooze command to leave the snooze window unchanged")
+	}
+
+	other := tgbotapi.Update{Message: &tgbotapi.Message{Text: "/snooze 30", Chat: &tgbotapi.Chat{ID: 1}}}
+	if monitor.IsConfiguredChat(other, cfg) {
+		t.Error("Expected update from another chat to be rejected")
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 // GetConfirmedBlocks returns a list of confirmed blocks between two slots of given range.
 func GetConfirmedBlocks(rangeStart int64, rangeEnd int64, cfg *config.Config) ([]int64, error) {
-	log.Println("Getting Confirmed Blocks...")
+	debugf("Getting Confirmed Blocks...")
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
@@ -37,8 +37,11 @@ func GetConfirmedBlocks(rangeStart int64, rangeEnd int64, cfg *config.Config) ([
 // GetConfirmedBlock takes current slot height and returns identity and transaction information about a
 // confirmed block in the ledger
 func GetConfirmedBlock(cfg *config.Config, height int64, node string) (types.ConfirmedBlock, error) {
-	log.Println("Getting Confirmed Block...")
+	debugf("Getting Confirmed Block...")
 	var result types.ConfirmedBlock
+	if err := checkSlotAvailable(cfg, node, height); err != nil {
+		return result, err
+	}
 	ops := types.HTTPOptions{
 		Method: http.MethodPost,
 		Body:   types.Payload{Jsonrpc: "2.0", Method: "getConfirmedBlock", ID: 1, Params: []interface{}{height}},
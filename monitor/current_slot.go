@@ -12,11 +12,30 @@ import (
 
 // GetCurrentSlot returns Current slot
 func GetCurrentSlot(cfg *config.Config, node string) (types.CurrentSlot, error) {
-	log.Println("Getting current slot")
+	debugf("Getting current slot")
+	return getSlot(cfg, node, commitment(cfg))
+}
+
+// GetFinalizedSlot returns the latest slot finalized by a supermajority of the cluster regardless of
+// the configured commitment
+func GetFinalizedSlot(cfg *config.Config, node string) (types.CurrentSlot, error) {
+	debugf("Getting finalized slot")
+	return getSlot(cfg, node, types.Commitment{Commitemnt: "finalized"})
+}
+
+// GetProcessedSlot returns the latest slot processed by the node, which may still be skipped by the cluster,
+// regardless of the configured commitment
+func GetProcessedSlot(cfg *config.Config, node string) (types.CurrentSlot, error) {
+	debugf("Getting processed slot")
+	return getSlot(cfg, node, types.Commitment{Commitemnt: "processed"})
+}
+
+// getSlot returns the slot of the given commitment
+func getSlot(cfg *config.Config, node string, c types.Commitment) (types.CurrentSlot, error) {
 	ops := types.HTTPOptions{
 		//Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method: http.MethodPost,
-		Body:   types.Payload{Jsonrpc: "2.0", Method: "getSlot", ID: 1},
+		Body:   types.Payload{Jsonrpc: "2.0", Method: "getSlot", ID: 1, Params: []interface{}{c}},
 	}
 
 	if node == utils.Network {
@@ -1,6 +1,9 @@
 package monitor_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/Chainflow/solana-mission-control/config"
@@ -47,3 +50,31 @@ func TestNetworkCurrentSlot(t *testing.T) {
 		t.Log("Got network current slot : ", res.Result)
 	}
 }
+
+func TestCurrentSlotCommitment(t *testing.T) {
+	var params []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []map[string]string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error("Error while decoding request : ", err)
+		}
+		params = req.Params
+		w.Write([]byte(`{"jsonrpc":"2.0","result":1010,"id":1}`))
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{Endpoints: config.Endpoints{RPCEndpoint: srv.URL, Commitment: "finalized"}}
+
+	res, err := monitor.GetCurrentSlot(cfg, utils.Validator)
+	if err != nil {
+		t.Fatal("Error while fetching current slot : ", err)
+	}
+	if res.Result != 1010 {
+		t.Error("Expected current slot 1010, got ", res.Result)
+	}
+	if len(params) != 1 || params[0]["commitment"] != "finalized" {
+		t.Error("Expected finalized commitment param, got ", params)
+	}
+}
@@ -0,0 +1,95 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+var (
+	// rpcEndpointsHealthy and rpcEndpointsTotal are the number of configured rpc endpoints whose latest request
+	// got a response without a server error and the number of distinct configured rpc endpoints
+	rpcEndpointsHealthy = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "solana_rpc_endpoints_healthy",
+			Help: "Number of configured rpc endpoints whose latest request succeeded",
+		})
+	rpcEndpointsTotal = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "solana_rpc_endpoints_total",
+			Help: "Number of distinct configured rpc endpoints",
+		})
+)
+
+// outcome of the latest request to each endpoint by host, and the severity of the last endpoint health alert
+var (
+	endpointHealthMu      sync.Mutex
+	endpointHealth        = make(map[string]bool)
+	endpointHealthAlerted string
+)
+
+// recordEndpointHealth records whether the latest request to the endpoint got a response without a server error,
+// after its retries
+func recordEndpointHealth(endpoint string, healthy bool) {
+	endpointHealthMu.Lock()
+	endpointHealth[endpointHost(endpoint)] = healthy
+	endpointHealthMu.Unlock()
+}
+
+// countHealthyEndpoints returns the number of the distinct configured rpc endpoints whose latest request succeeded
+// and their total. Endpoints which were not requested yet count as healthy.
+func countHealthyEndpoints(cfg *config.Config) (healthy, total int) {
+	endpointHealthMu.Lock()
+	defer endpointHealthMu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, endpoint := range []string{cfg.Endpoints.RPCEndpoint, cfg.Endpoints.NetworkRPC} {
+		host := endpointHost(endpoint)
+		if endpoint == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		total++
+		if ok, requested := endpointHealth[host]; ok || !requested {
+			healthy++
+		}
+	}
+	return healthy, total
+}
+
+// AlertEndpointHealth exports the number of healthy configured rpc endpoints and sends an alert when all of them
+// are down, or as a warning when fewer than the configured minimum are healthy
+func AlertEndpointHealth(cfg *config.Config) {
+	healthy, total := countHealthyEndpoints(cfg)
+	rpcEndpointsHealthy.Set(float64(healthy))
+	rpcEndpointsTotal.Set(float64(total))
+
+	var severity string
+	switch {
+	case total > 0 && healthy == 0:
+		severity = alerter.Critical
+	case int64(healthy) < cfg.AlertingThresholds.RPCEndpointsHealthyMin:
+		severity = alerter.Warning
+	}
+
+	endpointHealthMu.Lock()
+	alerted := endpointHealthAlerted
+	endpointHealthAlerted = severity
+	endpointHealthMu.Unlock()
+	if severity == "" || severity == alerted {
+		return
+	}
+
+	msg := fmt.Sprintf("RPC Endpoints Alert : Only %d of %d rpc endpoints are healthy, below the configured minimum %d", healthy, total, cfg.AlertingThresholds.RPCEndpointsHealthyMin)
+	if severity == alerter.Critical {
+		msg = fmt.Sprintf("RPC Endpoints Alert : None of the %d rpc endpoints are reachable, metrics and alerts can't be collected", total)
+	}
+	if err := alerter.SendAlert(alerter.EventRPCEndpoints, msg, severity, cfg); err != nil {
+		log.Printf("Error while sending rpc endpoints alert: %v", err)
+	}
+}
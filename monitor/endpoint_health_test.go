@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestCountHealthyEndpoints(t *testing.T) {
+	up := true
+	network := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","result":1010,"id":1}`))
+	}))
+	defer network.Close()
+	validator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":1010,"id":1}`))
+	}))
+	defer validator.Close()
+
+	cfg := &config.Config{Endpoints: config.Endpoints{RPCEndpoint: validator.URL, NetworkRPC: network.URL}}
+	request := func(endpoint string) {
+		ops := types.HTTPOptions{Endpoint: endpoint, Method: http.MethodPost, Body: types.Payload{Jsonrpc: "2.0", Method: "getSlot", ID: 1}}
+		HitHTTPTarget(ops)
+	}
+	expect := func(step string, healthy, total int) {
+		t.Helper()
+		h, n := countHealthyEndpoints(cfg)
+		if h != healthy || n != total {
+			t.Errorf("Expected %d of %d endpoints healthy %s, got %d of %d", healthy, total, step, h, n)
+		}
+	}
+
+	// endpoints not requested yet count as healthy
+	expect("before any request", 2, 2)
+
+	request(validator.URL)
+	request(network.URL)
+	expect("after successful requests", 2, 2)
+
+	up = false
+	request(network.URL)
+	expect("after the network rpc failed", 1, 2)
+
+	up = true
+	request(network.URL)
+	expect("after the network rpc recovered", 2, 2)
+
+	// the same endpoint configured twice counts once
+	cfg.Endpoints.NetworkRPC = validator.URL
+	expect("with one distinct endpoint", 1, 1)
+}
@@ -1,7 +1,6 @@
 package monitor
 
 import (
-	"encoding/json"
 	"log"
 	"net/http"
 
@@ -12,10 +11,10 @@ import (
 
 // GetEpochInfo returns information about the current epoch
 func GetEpochInfo(cfg *config.Config, node string) (types.EpochInfo, error) {
-	log.Println("Getting EpochInfo...")
+	debugf("Getting EpochInfo...")
 	ops := types.HTTPOptions{
 		Method: http.MethodPost,
-		Body:   types.Payload{Jsonrpc: "2.0", Method: "getEpochInfo", ID: 1},
+		Body:   types.Payload{Jsonrpc: "2.0", Method: "getEpochInfo", ID: 1, Params: []interface{}{commitment(cfg)}},
 	}
 
 	if node == utils.Network {
@@ -33,7 +32,7 @@ func GetEpochInfo(cfg *config.Config, node string) (types.EpochInfo, error) {
 		return result, err
 	}
 
-	err = json.Unmarshal(resp.Body, &result)
+	err = decodeRPCResponse(cfg, ops, resp.Body, &result, "epoch", "slotIndex", "slotsInEpoch", "absoluteSlot")
 	if err != nil {
 		log.Printf("Error: %v", err)
 		return result, err
@@ -0,0 +1,7 @@
+package monitor
+
+// ResetParseDegraded exports resetParseDegraded to the tests of package monitor_test
+var ResetParseDegraded = resetParseDegraded
+
+// ResetSOLPrice exports resetSOLPrice to the tests of package monitor_test
+var ResetSOLPrice = resetSOLPrice
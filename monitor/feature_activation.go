@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// featureProgramID is the address of the feature program which owns all feature accounts
+const featureProgramID = "Feature111111111111111111111111111111111111"
+
+// GetFeatureActivations returns the slot at which each of the given features was activated, features which are not
+// activated yet are left out. The state of a feature is read from its account, which holds an optional activation slot.
+func GetFeatureActivations(cfg *config.Config, node string, ids []string) (map[string]int64, error) {
+	debugf("Getting feature activations...")
+	ops := types.HTTPOptions{
+		Endpoint: cfg.Endpoints.RPCEndpoint,
+		Method:   http.MethodPost,
+		Body: types.Payload{Jsonrpc: "2.0", Method: "getMultipleAccounts", ID: 1, Params: []interface{}{
+			ids,
+			map[string]interface{}{"encoding": "base64", "commitment": commitment(cfg).Commitemnt},
+		}},
+	}
+	if node == utils.Network {
+		ops.Endpoint = cfg.Endpoints.NetworkRPC
+	}
+
+	var result types.MultipleAccounts
+	resp, err := HitHTTPTarget(ops)
+	if err != nil {
+		log.Printf("Error while getting feature accounts: %v", err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(resp.Body, &result)
+	if err != nil {
+		log.Printf("Error while unmarshelling feature accounts: %v", err)
+		return nil, err
+	}
+
+	if result.Error.Message != "" {
+		return nil, fmt.Errorf("RPC error: %v", result.Error.Message)
+	}
+	if len(result.Result.Value) != len(ids) {
+		return nil, fmt.Errorf("expected %d feature accounts, got %d", len(ids), len(result.Result.Value))
+	}
+
+	activated := make(map[string]int64)
+	for i, account := range result.Result.Value {
+		// the account of a feature is created when it is proposed
+		if account == nil || account.Owner != featureProgramID || len(account.Data) == 0 {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(account.Data[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid data of feature %s: %v", ids[i], err)
+		}
+		if slot, ok := featureActivationSlot(data); ok {
+			activated[ids[i]] = slot
+		}
+	}
+	return activated, nil
+}
+
+// featureActivationSlot decodes the data of a feature account, a bincode encoded Option<Slot> of the activation
+// slot, and returns the slot if the feature is activated
+func featureActivationSlot(data []byte) (int64, bool) {
+	if len(data) < 9 || data[0] != 1 {
+		return 0, false
+	}
+	return int64(binary.LittleEndian.Uint64(data[1:9])), true
+}
@@ -0,0 +1,84 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// firstAvailableBlockRefresh is the interval at which the first available block of a node is fetched again,
+// it only advances as the node prunes its ledger
+const firstAvailableBlockRefresh = 10 * time.Minute
+
+// ErrSlotPruned is returned by queries of slots before the first available block of the node
+var ErrSlotPruned = errors.New("slot is before the first available block of the node")
+
+// the first available block is cached by endpoint, as it is checked before every block query
+var (
+	firstBlockMu    sync.Mutex
+	firstBlockCache = make(map[string]firstAvailableBlock)
+)
+
+type firstAvailableBlock struct {
+	slot      int64
+	fetchedAt time.Time
+}
+
+// GetFirstAvailableBlock returns the slot of the lowest confirmed block which has not been purged from the
+// ledger of the node. It is fetched at most once per 10 minutes, in between the last fetched slot is returned.
+func GetFirstAvailableBlock(cfg *config.Config, node string) (int64, error) {
+	ops := types.HTTPOptions{
+		Method: http.MethodPost,
+		Body:   types.Payload{Jsonrpc: "2.0", Method: "getFirstAvailableBlock", ID: 1},
+	}
+	if node == utils.Network {
+		ops.Endpoint = cfg.Endpoints.NetworkRPC
+	} else {
+		ops.Endpoint = cfg.Endpoints.RPCEndpoint
+	}
+
+	firstBlockMu.Lock()
+	defer firstBlockMu.Unlock()
+
+	if cached, ok := firstBlockCache[ops.Endpoint]; ok && time.Since(cached.fetchedAt) < firstAvailableBlockRefresh {
+		return cached.slot, nil
+	}
+
+	debugf("Getting first available block...")
+	resp, err := HitHTTPTarget(ops)
+	if err != nil {
+		log.Printf("Error while getting first available block: %v", err)
+		return 0, err
+	}
+
+	var result types.FirstAvailableBlock
+	err = json.Unmarshal(resp.Body, &result)
+	if err != nil {
+		log.Printf("Error while unmarshelling first available block: %v", err)
+		return 0, err
+	}
+	if result.Error.Message != "" {
+		return 0, errors.New(result.Error.Message)
+	}
+
+	firstBlockCache[ops.Endpoint] = firstAvailableBlock{slot: result.Result, fetchedAt: time.Now()}
+	return result.Result, nil
+}
+
+// checkSlotAvailable returns ErrSlotPruned if the slot is before the first available block of the node. The
+// slot is assumed to be available when the first available block can't be fetched, the query then tells.
+func checkSlotAvailable(cfg *config.Config, node string, slot int64) error {
+	first, err := GetFirstAvailableBlock(cfg, node)
+	if err != nil || slot >= first {
+		return nil
+	}
+	return fmt.Errorf("%w: slot %d, first available block %d", ErrSlotPruned, slot, first)
+}
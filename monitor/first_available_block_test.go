@@ -0,0 +1,53 @@
+package monitor_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+func TestGetBlockTimeBeforeFirstAvailableBlock(t *testing.T) {
+	var blockTimeCalls int
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		switch req.Method {
+		case "getFirstAvailableBlock":
+			w.Write([]byte(`{"jsonrpc":"2.0","result":5000,"id":1}`))
+		case "getBlockTime":
+			blockTimeCalls++
+			w.Write([]byte(`{"jsonrpc":"2.0","result":1600000000,"id":1}`))
+		}
+	}))
+	defer rpc.Close()
+
+	cfg := &config.Config{Endpoints: config.Endpoints{RPCEndpoint: rpc.URL, NetworkRPC: rpc.URL}}
+
+	first, err := monitor.GetFirstAvailableBlock(cfg, utils.Validator)
+	if err != nil || first != 5000 {
+		t.Fatalf("Expected first available block 5000, got %d (%v)", first, err)
+	}
+
+	if _, err := monitor.GetBlockTime(4999, cfg); !errors.Is(err, monitor.ErrSlotPruned) {
+		t.Errorf("Expected pruned slot error, got %v", err)
+	}
+	if _, err := monitor.GetConfirmedBlock(cfg, 100, utils.Network); !errors.Is(err, monitor.ErrSlotPruned) {
+		t.Errorf("Expected pruned slot error of confirmed block, got %v", err)
+	}
+	if blockTimeCalls != 0 {
+		t.Errorf("Expected no block time query of a pruned slot, got %d", blockTimeCalls)
+	}
+
+	res, err := monitor.GetBlockTime(5000, cfg)
+	if err != nil || res.Result != 1600000000 {
+		t.Errorf("Expected block time of first available block, got %v (%v)", res.Result, err)
+	}
+}
@@ -0,0 +1,42 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// GetInflationReward returns the inflation reward of the given addresses for the given epoch
+func GetInflationReward(cfg *config.Config, addresses []string, epoch int64) (types.InflationReward, error) {
+	ops := types.HTTPOptions{
+		Endpoint: cfg.Endpoints.RPCEndpoint,
+		Method:   http.MethodPost,
+		Body: types.Payload{Jsonrpc: "2.0", Method: "getInflationReward", ID: 1, Params: []interface{}{
+			addresses,
+			map[string]interface{}{"epoch": epoch, "commitment": commitment(cfg).Commitemnt},
+		}},
+	}
+
+	var result types.InflationReward
+	resp, err := HitHTTPTarget(ops)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Body, &result)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return result, err
+	}
+
+	if result.Error.Message != "" {
+		return result, errors.New(result.Error.Message)
+	}
+
+	return result, nil
+}
@@ -9,13 +9,13 @@ import (
 	"github.com/Chainflow/solana-mission-control/types"
 )
 
-// GetLeaderSlots returns a map of slots associated with the given publickey
-func GetLeaderSlots(epochSlot int64, cfg *config.Config) (map[int64]string, error) {
-	log.Println("Getting LeaderSlot...")
+// GetLeaderSchedule returns the leader schedule of the epoch containing the given slot, keyed by identity.
+// The result is nil when the schedule of that epoch is not known yet.
+func GetLeaderSchedule(epochSlot int64, cfg *config.Config) (types.LeaderShedule, error) {
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
-		Body:     types.Payload{Jsonrpc: "2.0", Method: "getLeaderSchedule", ID: 1, Params: []interface{}{epochSlot}},
+		Body:     types.Payload{Jsonrpc: "2.0", Method: "getLeaderSchedule", ID: 1, Params: []interface{}{epochSlot, commitment(cfg)}},
 	}
 
 	var sch types.LeaderShedule
@@ -23,12 +23,23 @@ func GetLeaderSlots(epochSlot int64, cfg *config.Config) (map[int64]string, erro
 	resp, err := HitHTTPTarget(ops)
 	if err != nil {
 		log.Printf("Error while getting leader shedules: %v", err)
-		return nil, err
+		return sch, err
 	}
 
 	err = json.Unmarshal(resp.Body, &sch)
 	if err != nil {
 		log.Printf("Error while unmarshelling leader shedules: %v", err)
+		return sch, err
+	}
+
+	return sch, nil
+}
+
+// GetLeaderSlots returns a map of slots associated with the given publickey
+func GetLeaderSlots(epochSlot int64, cfg *config.Config) (map[int64]string, error) {
+	debugf("Getting LeaderSlot...")
+	sch, err := GetLeaderSchedule(epochSlot, cfg)
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,94 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// startupOnce makes sure the startup alert is sent only once
+var startupOnce sync.Once
+
+// SendStartupAlert sends the startup notification with the monitored validator, its version, the endpoints
+// in use and the enabled alert channels, so that operators know monitoring is live after a deploy.
+// It's sent only once and only when startup alerts are enabled.
+func SendStartupAlert(cfg *config.Config) {
+	if !strings.EqualFold(cfg.AlerterPreferences.StartupAlerts, "yes") {
+		return
+	}
+
+	startupOnce.Do(func() {
+		msg := "Solana Mission Control started up.\n" + lifecycleDetails(cfg) + GetEpochDetails(cfg)
+		if !cfg.IsRPCNode() {
+			activatedStake := float64(-1)
+			voteAccs, err := GetVoteAccounts(cfg, cfg.RPCSource(utils.StakeGroup, utils.Network))
+			if err != nil {
+				log.Printf("Error while getting vote accounts: %v", err)
+			} else {
+				for _, vote := range voteAccs.Result.Current {
+					if vote.NodePubkey == cfg.ValDetails.PubKey {
+						activatedStake = float64(vote.ActivatedStake) / math.Pow(10, 9)
+						break
+					}
+				}
+			}
+			msg = msg + fmt.Sprintf("Activated Stake: %.4f", activatedStake)
+		}
+
+		if err := alerter.SendAlert(alerter.EventStartup, msg, alerter.Info, cfg); err != nil {
+			log.Printf("Error while sending startup alert: %v", err)
+		}
+	})
+}
+
+// SendShutdownAlert sends the shutdown notification on a graceful exit when startup alerts are enabled
+func SendShutdownAlert(cfg *config.Config, reason string) {
+	if !strings.EqualFold(cfg.AlerterPreferences.StartupAlerts, "yes") {
+		return
+	}
+
+	msg := fmt.Sprintf("Solana Mission Control is shutting down (%s), alerts will stop.\n", reason) + lifecycleDetails(cfg)
+	if err := alerter.SendAlert(alerter.EventShutdown, msg, alerter.Info, cfg); err != nil {
+		log.Printf("Error while sending shutdown alert: %v", err)
+	}
+}
+
+// lifecycleDetails returns the validator, version, endpoints and alert channels of the startup and shutdown alerts
+func lifecycleDetails(cfg *config.Config) string {
+	version := "unknown"
+	if v, err := GetVersion(cfg); err != nil {
+		log.Printf("Error while getting version : %v", err)
+	} else if v.Result.SolanaCore != "" {
+		version = v.Result.SolanaCore
+	}
+
+	var channels []string
+	if cfg.EnableAlerts.EnableTelegramAlerts {
+		channels = append(channels, "telegram")
+	}
+	if cfg.EnableAlerts.EnableEmailAlerts {
+		channels = append(channels, "email")
+	}
+	if cfg.EnableAlerts.EnableSlackAlerts {
+		channels = append(channels, "slack")
+	}
+
+	return fmt.Sprintf("Validator: %s\nVersion: %s\nValidator RPC: %s\nNetwork RPC: %s\nAlert Channels: %s\n",
+		cfg.ValDetails.ValidatorName, version, endpointHost(cfg.Endpoints.RPCEndpoint), endpointHost(cfg.Endpoints.NetworkRPC), strings.Join(channels, ", "))
+}
+
+// endpointHost returns only the host of the given endpoint, as the path or query may contain an api key
+func endpointHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+	return u.Host
+}
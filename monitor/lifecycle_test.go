@@ -0,0 +1,64 @@
+package monitor_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+func TestSendStartupAlertOnce(t *testing.T) {
+	t.Cleanup(monitor.ResetParseDegraded)
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		results := map[string]string{
+			"getVersion":      `{"solana-core": "1.14.17"}`,
+			"getEpochInfo":    `{"absoluteSlot": 1010, "blockHeight": 900, "epoch": 100, "slotIndex": 10, "slotsInEpoch": 432000}`,
+			"getVoteAccounts": `{"current": [{"activatedStake": 5000000000000, "epochCredits": [[100, 4000, 3000]], "lastVote": 1000, "nodePubkey": "valPubKey", "votePubkey": "valVoteKey"}], "delinquent": []}`,
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%s,"id":1}`, results[req.Method])
+	}))
+	defer rpc.Close()
+
+	var texts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		texts = append(texts, payload["text"])
+	}))
+	defer slack.Close()
+
+	cfg := &config.Config{
+		Endpoints:          config.Endpoints{RPCEndpoint: rpc.URL, NetworkRPC: rpc.URL},
+		ValDetails:         config.ValDetails{ValidatorName: "test-val", PubKey: "valPubKey"},
+		EnableAlerts:       config.EnableAlerts{EnableSlackAlerts: true},
+		Slack:              config.Slack{WebhookURL: slack.URL},
+		AlerterPreferences: config.AlerterPreferences{StartupAlerts: "yes"},
+	}
+
+	monitor.SendStartupAlert(cfg)
+	monitor.SendStartupAlert(cfg)
+
+	if len(texts) != 1 {
+		t.Fatalf("Expected startup alert to be sent once, got %d", len(texts))
+	}
+	for _, want := range []string{"test-val", "1.14.17", "Alert Channels: slack", "Activated Stake: 5000.0000"} {
+		if !strings.Contains(texts[0], want) {
+			t.Errorf("Expected startup alert to contain %q, got %s", want, texts[0])
+		}
+	}
+
+	cfg.AlerterPreferences.StartupAlerts = "no"
+	monitor.SendShutdownAlert(cfg, "terminated")
+	if len(texts) != 1 {
+		t.Errorf("Expected no shutdown alert when startup alerts are disabled, got %d alerts", len(texts))
+	}
+}
@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// defaultLogMaxSize is the size in megabytes at which the log file is rotated if not configured
+const defaultLogMaxSize = 100
+
+// debugLogging is whether debug logs like every rpc request are written, set from the configured log level
+var debugLogging bool
+
+// debugf logs like log.Printf if the configured log level is debug
+func debugf(format string, v ...interface{}) {
+	if debugLogging {
+		log.Printf(format, v...)
+	}
+}
+
+// ConfigureLogging writes the logs to the configured file, rotated by size and age, instead of stderr and
+// formats them as json if configured. Debug logs are only written at the debug log level. Both the standard
+// logger and logrus are configured, for json the standard logger writes through logrus so every line is a
+// json object.
+func ConfigureLogging(cfg *config.Config) error {
+	debugLogging = cfg.Log.Level == "debug"
+
+	var out io.Writer = os.Stderr
+	if cfg.Log.File != "" {
+		// the file is opened lazily on the first write, fail at startup if it can't be written instead
+		f, err := os.OpenFile(cfg.Log.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("log file can't be written: %v", err)
+		}
+		f.Close()
+
+		maxSize := cfg.Log.MaxSize
+		if maxSize <= 0 {
+			maxSize = defaultLogMaxSize
+		}
+		out = &lumberjack.Logger{
+			Filename:   cfg.Log.File,
+			MaxSize:    maxSize,
+			MaxAge:     cfg.Log.MaxAge,
+			MaxBackups: cfg.Log.MaxBackups,
+			Compress:   cfg.Log.Compress,
+		}
+	}
+
+	logrus.SetOutput(out)
+	if cfg.Log.Format == "json" {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+		log.SetFlags(0)
+		log.SetOutput(logrus.StandardLogger().Writer())
+		return nil
+	}
+
+	logrus.SetFormatter(&logrus.TextFormatter{FullTimestamp: true, DisableColors: cfg.Log.File != ""})
+	log.SetFlags(log.LstdFlags)
+	log.SetOutput(out)
+	return nil
+}
@@ -0,0 +1,37 @@
+package monitor_test
+
+import (
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+func TestConfigureLogging(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "solana-mc.log")
+
+	cfg := &config.Config{Log: config.Log{File: file, MaxSize: 1}}
+	if err := monitor.ConfigureLogging(cfg); err != nil {
+		t.Fatal("Error while configuring logging :", err)
+	}
+	defer monitor.ConfigureLogging(&config.Config{})
+
+	log.Printf("Getting current slot")
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal("Error while reading log file :", err)
+	}
+	if !strings.Contains(string(data), "Getting current slot") {
+		t.Errorf("Expected log line in %s, got %q", file, data)
+	}
+
+	cfg.Log.File = filepath.Join(t.TempDir(), "missing", "solana-mc.log")
+	if err := monitor.ConfigureLogging(cfg); err == nil {
+		t.Error("Expected error for a log file in a missing directory")
+	}
+}
@@ -6,15 +6,17 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/Chainflow/solana-mission-control/alerter"
 	"github.com/Chainflow/solana-mission-control/config"
 	"github.com/Chainflow/solana-mission-control/types"
 )
 
-// GetNodeHealth returns the current health of the node.
-func GetNodeHealth(cfg *config.Config) (float64, error) {
-	log.Println("Getting Node Health...")
+// GetNodeHealth returns the current health of the node and the number of slots it is behind the cluster,
+// which an unhealthy node tells in its error. Slots behind are 0 if the node is healthy or doesn't tell.
+func GetNodeHealth(cfg *config.Config) (float64, int64, error) {
+	debugf("Getting Node Health...")
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
@@ -26,13 +28,13 @@ func GetNodeHealth(cfg *config.Config) (float64, error) {
 	resp, err := HitHTTPTarget(ops)
 	if err != nil {
 		log.Printf("Error: %v", err)
-		return h, err
+		return h, 0, err
 	}
 
 	err = json.Unmarshal(resp.Body, &result)
 	if err != nil {
 		log.Printf("Error: %v", err)
-		return h, err
+		return h, 0, err
 	}
 
 	// send alert if node is down
@@ -40,26 +42,58 @@ func GetNodeHealth(cfg *config.Config) (float64, error) {
 		if strings.EqualFold(result.Result, "ok") {
 			log.Printf("Node health : %s", result.Result)
 			h = 1
+			if err := alerter.Escalate(alerter.EventNodeDown, "", false, cfg); err != nil {
+				log.Printf("Error while escalating node health alert: %v", err)
+			}
+			alertSlotsBehind(cfg, 0)
 
-			return h, nil
+			return h, 0, nil
 		} else {
 			if strings.EqualFold(cfg.AlerterPreferences.NodeHealthAlert, "yes") {
-				err = alerter.SendTelegramAlert(fmt.Sprintf("Your node is not running"), cfg)
-				if err != nil {
-					log.Printf("Error while sending node health alert to telegram: %v", err)
-				}
-				err = alerter.SendEmailAlert(fmt.Sprintf("Your node is not running"), cfg)
-				if err != nil {
-					log.Printf("Error while sending node health alert to email: %v", err)
+				// the alert is repeated at the escalation intervals if configured instead of on every check
+				if !alerter.Escalating(alerter.EventNodeDown) {
+					err = alerter.SendAlert(alerter.EventNodeDown, alerter.Localize(cfg, alerter.MsgNodeDown), alerter.Critical, cfg)
+					if err != nil {
+						log.Printf("Error while sending node health alert: %v", err)
+					}
 				}
-				err = alerter.SendSlackAlert(fmt.Sprintf("Your node is not running"), cfg)
-				if err != nil {
-					log.Printf("Error while sending node health alert to slack: %v", err)
+				if err := alerter.Escalate(alerter.EventNodeDown, alerter.Localize(cfg, alerter.MsgNodeDown), true, cfg); err != nil {
+					log.Printf("Error while escalating node health alert: %v", err)
 				}
 				h = 0
 			}
 		}
 	}
 
-	return h, nil
+	slotsBehind := result.Error.Data.NumSlotsBehind
+	if slotsBehind > 0 {
+		log.Printf("Node health : %s", result.Error.Message)
+	}
+	alertSlotsBehind(cfg, slotsBehind)
+
+	return h, slotsBehind, nil
+}
+
+// slotsBehindAlerted is whether the node has been reported to be behind by at least the threshold, to alert once
+var (
+	slotsBehindMu      sync.Mutex
+	slotsBehindAlerted bool
+)
+
+// alertSlotsBehind sends an alert once when the node falls behind the cluster by at least the configured threshold
+func alertSlotsBehind(cfg *config.Config, slotsBehind int64) {
+	threshold := cfg.AlertingThresholds.SlotsBehindThreshold
+	behind := threshold > 0 && slotsBehind >= threshold
+
+	slotsBehindMu.Lock()
+	alerted := slotsBehindAlerted
+	slotsBehindAlerted = behind
+	slotsBehindMu.Unlock()
+
+	if behind && !alerted {
+		err := alerter.SendAlert(alerter.EventSlotsBehind, fmt.Sprintf("Slots Behind Alert : Your node is %d slots behind the cluster, reaching the configured threshold %d", slotsBehind, threshold), alerter.Warning, cfg)
+		if err != nil {
+			log.Printf("Error while sending slots behind alert: %v", err)
+		}
+	}
 }
@@ -1,6 +1,10 @@
 package monitor_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/Chainflow/solana-mission-control/config"
@@ -12,7 +16,7 @@ func TestGetNodeHealth(t *testing.T) {
 	if err != nil {
 		t.Error("Error while reading config :", err)
 	}
-	res, err := monitor.GetNodeHealth(cfg)
+	res, _, err := monitor.GetNodeHealth(cfg)
 	if err != nil {
 		t.Error("Error while fetching Node Health")
 	}
@@ -23,3 +27,42 @@ func TestGetNodeHealth(t *testing.T) {
 		t.Log("Got Node Health", res)
 	}
 }
+
+func TestGetNodeHealthSlotsBehind(t *testing.T) {
+	health := `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 120 slots","data":{"numSlotsBehind":120}},"id":1}`
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(health))
+	}))
+	defer rpc.Close()
+
+	var alerts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		alerts = append(alerts, payload["text"])
+	}))
+	defer slack.Close()
+
+	cfg := &config.Config{
+		Endpoints:          config.Endpoints{RPCEndpoint: rpc.URL},
+		EnableAlerts:       config.EnableAlerts{EnableSlackAlerts: true},
+		Slack:              config.Slack{WebhookURL: slack.URL},
+		AlertingThresholds: config.AlertingThreshold{SlotsBehindThreshold: 100},
+	}
+
+	for i := 0; i < 2; i++ {
+		h, behind, err := monitor.GetNodeHealth(cfg)
+		if err != nil || h != 0 || behind != 120 {
+			t.Fatalf("Expected unhealthy node 120 slots behind, got health %v and %d slots behind (%v)", h, behind, err)
+		}
+	}
+	if len(alerts) != 1 || !strings.Contains(alerts[0], "120 slots behind") {
+		t.Errorf("Expected one slots behind alert, got %v", alerts)
+	}
+
+	health = `{"jsonrpc":"2.0","result":"ok","id":1}`
+	h, behind, err := monitor.GetNodeHealth(cfg)
+	if err != nil || h != 1 || behind != 0 {
+		t.Errorf("Expected healthy node, got health %v and %d slots behind (%v)", h, behind, err)
+	}
+}
@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// rpcParseErrors counts the rpc responses which can't be parsed or miss expected fields
+var rpcParseErrors = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "solana_rpc_parse_errors_total",
+		Help: "Number of rpc responses which could not be parsed or miss expected fields, grouped by rpc method",
+	},
+	[]string{"method"})
+
+// RegisterMetrics registers the metrics of the monitor with the given registerer
+func RegisterMetrics(r prometheus.Registerer) {
+	r.MustRegister(rpcParseErrors)
+	r.MustRegister(rpcRateLimitRemaining)
+	r.MustRegister(rpcRateLimitLimit)
+	r.MustRegister(rpcEndpointsHealthy)
+	r.MustRegister(rpcEndpointsTotal)
+}
+
+// parseDegraded holds the rpc methods whose responses currently fail to parse, to alert once when parsing degrades
+var (
+	parseMu       sync.Mutex
+	parseDegraded = make(map[string]bool)
+)
+
+// resetParseDegraded forgets which rpc methods fail to parse, so that the next parse error alerts again
+func resetParseDegraded() {
+	parseMu.Lock()
+	parseDegraded = make(map[string]bool)
+	parseMu.Unlock()
+}
+
+// decodeRPCResponse parses the response of the rpc request into v after checking that its result holds the
+// given fields, so that a changed response shape fails loudly instead of producing zero metrics. Each
+// field is a path of keys where * stands for every element of an array e.g. current.*.lastVote.
+// An rpc error is returned as is and is not a parse error.
+func decodeRPCResponse(cfg *config.Config, ops types.HTTPOptions, body []byte, v interface{}, fields ...string) error {
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int64  `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return parseError(cfg, ops, fmt.Errorf("invalid response of %s: %v", ops.Body.Method, err))
+	}
+	if envelope.Error != nil {
+		return fmt.Errorf("RPC error: %d %v", envelope.Error.Code, envelope.Error.Message)
+	}
+
+	var missing []string
+	for _, field := range fields {
+		missing = append(missing, missingFields(envelope.Result, "", strings.Split(field, "."))...)
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return parseError(cfg, ops, fmt.Errorf("response of %s misses fields %s", ops.Body.Method, strings.Join(missing, ", ")))
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return parseError(cfg, ops, fmt.Errorf("invalid response of %s: %v", ops.Body.Method, err))
+	}
+
+	parseMu.Lock()
+	delete(parseDegraded, ops.Body.Method)
+	parseMu.Unlock()
+	return nil
+}
+
+// missingFields returns the paths below the given prefix of the given keys which are missing from the json value
+func missingFields(value json.RawMessage, prefix string, keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+	if len(value) == 0 || string(value) == "null" {
+		return []string{prefix + strings.Join(keys, ".")}
+	}
+
+	if keys[0] == "*" {
+		var elems []json.RawMessage
+		if err := json.Unmarshal(value, &elems); err != nil {
+			return []string{strings.TrimSuffix(prefix, ".")}
+		}
+		// the first missing field of all elements is enough to point at the change
+		for i, elem := range elems {
+			if missing := missingFields(elem, fmt.Sprintf("%s%d.", prefix, i), keys[1:]); len(missing) != 0 {
+				return missing
+			}
+		}
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(value, &obj); err != nil {
+		return []string{strings.TrimSuffix(prefix, ".")}
+	}
+	field, ok := obj[keys[0]]
+	if !ok {
+		return []string{prefix + keys[0]}
+	}
+	return missingFields(field, prefix+keys[0]+".", keys[1:])
+}
+
+// parseError counts the parse error of the response of the rpc request, reports it to sentry and sends an alert
+// once when parsing of the rpc method starts failing
+func parseError(cfg *config.Config, ops types.HTTPOptions, err error) error {
+	log.Printf("Error while parsing rpc response : %v", err)
+	rpcParseErrors.WithLabelValues(ops.Body.Method).Inc()
+	reportRPCError(ops, "schema", err)
+
+	parseMu.Lock()
+	degraded := parseDegraded[ops.Body.Method]
+	parseDegraded[ops.Body.Method] = true
+	parseMu.Unlock()
+
+	if !degraded {
+		alertErr := alerter.SendAlert(alerter.EventRPCParse, fmt.Sprintf("RPC Parse Alert : Responses of %s from %s can't be parsed, metrics depending on it are not exported : %v",
+			ops.Body.Method, endpointHost(ops.Endpoint), err), alerter.Warning, cfg)
+		if alertErr != nil {
+			log.Printf("Error while sending rpc parse alert: %v", alertErr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package monitor_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+func TestGetVoteAccountsMalformed(t *testing.T) {
+	monitor.ResetParseDegraded()
+	t.Cleanup(monitor.ResetParseDegraded)
+	// lastVote was renamed in the response
+	voteAccounts := `{"current": [{"activatedStake": 5000000000000, "commission": 10, "epochCredits": [[100, 4000, 3000]], "epochVoteAccount": true, "lastVoteSlot": 1000, "nodePubkey": "valPubKey", "rootSlot": 968, "votePubkey": "valVoteKey"}], "delinquent": []}`
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":` + voteAccounts + `,"id":1}`))
+	}))
+	defer rpc.Close()
+
+	var alerts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		alerts = append(alerts, payload["text"])
+	}))
+	defer slack.Close()
+
+	cfg := &config.Config{
+		Endpoints:    config.Endpoints{RPCEndpoint: rpc.URL, NetworkRPC: rpc.URL},
+		EnableAlerts: config.EnableAlerts{EnableSlackAlerts: true},
+		Slack:        config.Slack{WebhookURL: slack.URL},
+	}
+
+	reg := prometheus.NewRegistry()
+	monitor.RegisterMetrics(reg)
+	parseErrors := func() float64 {
+		families, err := reg.Gather()
+		if err != nil {
+			t.Fatal("Error while gathering metrics :", err)
+		}
+		for _, family := range families {
+			for _, m := range family.GetMetric() {
+				if family.GetName() == "solana_rpc_parse_errors_total" && m.GetLabel()[0].GetValue() == "getVoteAccounts" {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+		return 0
+	}
+	before := parseErrors()
+
+	for i := 0; i < 2; i++ {
+		_, err := monitor.GetVoteAccounts(cfg, utils.Validator)
+		if err == nil || !strings.Contains(err.Error(), "current.0.lastVote") {
+			t.Fatalf("Expected error naming the missing field, got %v", err)
+		}
+	}
+
+	if got := parseErrors() - before; got != 2 {
+		t.Errorf("Expected 2 parse errors, got %v", got)
+	}
+	if len(alerts) != 1 || !strings.Contains(alerts[0], "getVoteAccounts") {
+		t.Errorf("Expected one rpc parse alert, got %v", alerts)
+	}
+
+	// the alert is sent again only after parsing recovered and degrades again
+	voteAccounts = strings.Replace(voteAccounts, "lastVoteSlot", "lastVote", 1)
+	res, err := monitor.GetVoteAccounts(cfg, utils.Validator)
+	if err != nil || len(res.Result.Current) != 1 || res.Result.Current[0].LastVote != 1000 {
+		t.Fatalf("Expected vote accounts after recovery, got %v (%v)", res.Result, err)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("Expected no alert after recovery, got %v", alerts)
+	}
+}
@@ -0,0 +1,86 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// defaultPriceRefreshInterval is the interval at which SOL price is fetched again if not configured
+const defaultPriceRefreshInterval = 5 * time.Minute
+
+// SOL price is cached to respect the rate limits of the price provider
+var (
+	priceMu     sync.Mutex
+	cachedPrice float64
+	// priceFetched is whether a price was ever fetched successfully, cachedPrice is meaningless until then
+	priceFetched bool
+	// priceAttemptedAt is when the price was last requested, whether or not the request succeeded
+	priceAttemptedAt time.Time
+)
+
+// GetSOLPrice returns the SOL price in USD from the configured price provider.
+// The price is fetched at most once per refresh interval, in between the last fetched price is returned.
+// Until a price was fetched successfully an error is returned, so that no price of 0 is exported.
+func GetSOLPrice(cfg *config.Config) (float64, error) {
+	priceMu.Lock()
+	defer priceMu.Unlock()
+
+	interval := priceRefreshInterval(cfg)
+	if !priceAttemptedAt.IsZero() && time.Since(priceAttemptedAt) < interval {
+		if !priceFetched {
+			return 0, fmt.Errorf("no SOL price fetched yet, retrying after %s", interval)
+		}
+		return cachedPrice, nil
+	}
+
+	// failed fetches are not retried before the refresh interval either
+	priceAttemptedAt = time.Now()
+
+	ops := types.HTTPOptions{
+		Endpoint: cfg.Price.PriceProvider,
+		Method:   http.MethodGet,
+	}
+
+	resp, err := HitHTTPTarget(ops)
+	if err != nil {
+		log.Printf("Error while getting SOL price: %v", err)
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("price provider returned status %d", resp.StatusCode)
+	}
+
+	var result types.SOLPrice
+	err = json.Unmarshal(resp.Body, &result)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return 0, err
+	}
+
+	cachedPrice, priceFetched = result.Solana.USD, true
+
+	return cachedPrice, nil
+}
+
+// resetSOLPrice forgets the cached SOL price, so that the next call fetches it again
+func resetSOLPrice() {
+	priceMu.Lock()
+	cachedPrice, priceFetched, priceAttemptedAt = 0, false, time.Time{}
+	priceMu.Unlock()
+}
+
+// priceRefreshInterval returns the configured interval at which SOL price is fetched again
+func priceRefreshInterval(cfg *config.Config) time.Duration {
+	d, err := time.ParseDuration(cfg.Price.RefreshInterval)
+	if err != nil || d <= 0 {
+		return defaultPriceRefreshInterval
+	}
+	return d
+}
@@ -0,0 +1,60 @@
+package monitor_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+)
+
+func TestGetSOLPrice(t *testing.T) {
+	monitor.ResetSOLPrice()
+	t.Cleanup(monitor.ResetSOLPrice)
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`{"solana":{"usd":23.45}}`))
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{Price: config.Price{EnablePrice: true, PriceProvider: srv.URL}}
+
+	for i := 0; i < 2; i++ {
+		price, err := monitor.GetSOLPrice(cfg)
+		if err != nil {
+			t.Fatal("Error while fetching SOL price : ", err)
+		}
+		if price != 23.45 {
+			t.Error("Expected SOL price 23.45, got ", price)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("Expected SOL price to be fetched once and cached, got %d requests", hits)
+	}
+}
+
+func TestGetSOLPriceFirstFetchFails(t *testing.T) {
+	monitor.ResetSOLPrice()
+	t.Cleanup(monitor.ResetSOLPrice)
+
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{Price: config.Price{EnablePrice: true, PriceProvider: srv.URL}}
+
+	// no price of 0 is returned before a price was fetched, and the provider isn't asked again before the interval
+	for i := 0; i < 2; i++ {
+		if price, err := monitor.GetSOLPrice(cfg); err == nil {
+			t.Errorf("Expected error before a price was fetched, got price %v", price)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("Expected SOL price to be requested once within the refresh interval, got %d requests", hits)
+	}
+}
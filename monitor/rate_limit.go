@@ -0,0 +1,120 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Chainflow/solana-mission-control/alerter"
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+var (
+	// rpcRateLimitRemaining and rpcRateLimitLimit are the rate limit headroom of each rpc endpoint which sends
+	// rate limit headers, endpoints without them are not exported
+	rpcRateLimitRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_rpc_rate_limit_remaining",
+			Help: "Requests remaining in the current rate limit window of the rpc endpoint, from its rate limit headers",
+		},
+		[]string{"endpoint"})
+	rpcRateLimitLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "solana_rpc_rate_limit_limit",
+			Help: "Requests allowed per rate limit window of the rpc endpoint, from its rate limit headers",
+		},
+		[]string{"endpoint"})
+)
+
+// rateLimitHeaders are the pairs of remaining and limit headers sent by rpc providers, the first present one is used
+var rateLimitHeaders = [][2]string{
+	{"X-RateLimit-Remaining", "X-RateLimit-Limit"},
+	{"RateLimit-Remaining", "RateLimit-Limit"},
+}
+
+// rateLimit is the rate limit headroom reported by an rpc endpoint
+type rateLimit struct {
+	remaining, limit int64
+}
+
+// rate limits last reported by each rpc endpoint, and the endpoints whose low headroom was alerted
+var (
+	rateLimitMu      sync.Mutex
+	rateLimits       = make(map[string]rateLimit)
+	rateLimitAlerted = make(map[string]bool)
+)
+
+// parseRateLimit returns the remaining requests and the limit of the rate limit headers, ok is false if the
+// response has no rate limit headers. A limit of 0 means it is not sent. Values with a policy like
+// 100;w=10 or a list of limits are reduced to their first number.
+func parseRateLimit(h http.Header) (rl rateLimit, ok bool) {
+	for _, names := range rateLimitHeaders {
+		remaining, err := parseRateLimitValue(h.Get(names[0]))
+		if err != nil {
+			continue
+		}
+		limit, _ := parseRateLimitValue(h.Get(names[1]))
+		return rateLimit{remaining: remaining, limit: limit}, true
+	}
+	return rateLimit{}, false
+}
+
+// parseRateLimitValue returns the first number of the rate limit header value
+func parseRateLimitValue(v string) (int64, error) {
+	if i := strings.IndexAny(v, ",;"); i >= 0 {
+		v = v[:i]
+	}
+	return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+}
+
+// recordRateLimit exports the rate limit headroom of the rpc endpoint if its response has rate limit headers
+func recordRateLimit(endpoint string, h http.Header) {
+	rl, ok := parseRateLimit(h)
+	if !ok {
+		return
+	}
+	host := endpointHost(endpoint)
+	rpcRateLimitRemaining.WithLabelValues(host).Set(float64(rl.remaining))
+	if rl.limit > 0 {
+		rpcRateLimitLimit.WithLabelValues(host).Set(float64(rl.limit))
+	}
+
+	rateLimitMu.Lock()
+	rateLimits[host] = rl
+	rateLimitMu.Unlock()
+}
+
+// AlertRateLimits sends an alert once for each rpc endpoint whose remaining requests drop below the configured
+// percentage of its rate limit, so that the scrape interval can be adjusted before requests are throttled
+func AlertRateLimits(cfg *config.Config) {
+	threshold := cfg.AlertingThresholds.RPCRateLimitHeadroomPercent
+	if threshold <= 0 {
+		return
+	}
+
+	rateLimitMu.Lock()
+	var low []string
+	for host, rl := range rateLimits {
+		below := rl.limit > 0 && float64(rl.remaining)*100 < threshold*float64(rl.limit)
+		if below && !rateLimitAlerted[host] {
+			low = append(low, fmt.Sprintf("%s (%d of %d remaining)", host, rl.remaining, rl.limit))
+		}
+		rateLimitAlerted[host] = below
+	}
+	rateLimitMu.Unlock()
+
+	sort.Strings(low)
+	for _, endpoint := range low {
+		err := alerter.SendAlert(alerter.EventRPCRateLimit, fmt.Sprintf("RPC Rate Limit Alert : The rate limit headroom of %s is below %.0f%%, increase the scrape interval before requests are throttled",
+			endpoint, threshold), alerter.Warning, cfg)
+		if err != nil {
+			log.Printf("Error while sending rpc rate limit alert: %v", err)
+		}
+	}
+}
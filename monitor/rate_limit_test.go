@@ -0,0 +1,50 @@
+package monitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	for _, tc := range []struct {
+		header   http.Header
+		expected rateLimit
+		ok       bool
+	}{
+		{http.Header{"X-Ratelimit-Remaining": {"42"}, "X-Ratelimit-Limit": {"100"}}, rateLimit{42, 100}, true},
+		{http.Header{"Ratelimit-Remaining": {"7"}, "Ratelimit-Limit": {"10, 10;w=1, 1000;w=3600"}}, rateLimit{7, 10}, true},
+		// remaining without a limit is exported, but there is no headroom to compare
+		{http.Header{"X-Ratelimit-Remaining": {"5"}}, rateLimit{5, 0}, true},
+		{http.Header{"Content-Type": {"application/json"}}, rateLimit{}, false},
+		{http.Header{"X-Ratelimit-Remaining": {"many"}}, rateLimit{}, false},
+	} {
+		rl, ok := parseRateLimit(tc.header)
+		if ok != tc.ok || rl != tc.expected {
+			t.Errorf("Expected %+v (%v) for %v, got %+v (%v)", tc.expected, tc.ok, tc.header, rl, ok)
+		}
+	}
+}
+
+func TestRecordRateLimit(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "3")
+		w.Header().Set("X-RateLimit-Limit", "40")
+		w.Write([]byte(`{"jsonrpc":"2.0","result":1010,"id":1}`))
+	}))
+	defer rpc.Close()
+
+	ops := types.HTTPOptions{Endpoint: rpc.URL, Method: http.MethodPost, Body: types.Payload{Jsonrpc: "2.0", Method: "getSlot", ID: 1}}
+	if _, err := HitHTTPTarget(ops); err != nil {
+		t.Fatal("Error while requesting rpc :", err)
+	}
+
+	rateLimitMu.Lock()
+	rl, ok := rateLimits[endpointHost(rpc.URL)]
+	rateLimitMu.Unlock()
+	if !ok || rl != (rateLimit{3, 40}) {
+		t.Errorf("Expected rate limit of 3 of 40 requests recorded, got %+v (%v)", rl, ok)
+	}
+}
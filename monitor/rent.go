@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// Data sizes in bytes of the accounts of a validator, used to get their rent exempt minimum balance
+const (
+	IdentityAccountSize = 0
+	VoteAccountSize     = 3762
+)
+
+// rentRefresh is the interval at which the rent exempt minimum of an account size is fetched again,
+// the rent of the cluster rarely changes
+const rentRefresh = time.Hour
+
+// rent exempt minimum balances are cached by account size
+var (
+	rentMu    sync.Mutex
+	rentCache = make(map[int]rentMinimum)
+)
+
+type rentMinimum struct {
+	lamports  int64
+	fetchedAt time.Time
+}
+
+// GetMinimumBalanceForRentExemption returns the minimum balance in lamports for an account of the given data size
+// to be rent exempt. It is fetched at most once per hour, in between the last fetched balance is returned.
+func GetMinimumBalanceForRentExemption(cfg *config.Config, size int) (int64, error) {
+	rentMu.Lock()
+	defer rentMu.Unlock()
+
+	if cached, ok := rentCache[size]; ok && time.Since(cached.fetchedAt) < rentRefresh {
+		return cached.lamports, nil
+	}
+
+	debugf("Getting minimum balance for rent exemption...")
+	ops := types.HTTPOptions{
+		Endpoint: cfg.Endpoints.RPCEndpoint,
+		Method:   http.MethodPost,
+		Body:     types.Payload{Jsonrpc: "2.0", Method: "getMinimumBalanceForRentExemption", ID: 1, Params: []interface{}{size}},
+	}
+	resp, err := HitHTTPTarget(ops)
+	if err != nil {
+		log.Printf("Error while getting minimum balance for rent exemption: %v", err)
+		return 0, err
+	}
+
+	var result types.MinimumBalance
+	err = json.Unmarshal(resp.Body, &result)
+	if err != nil {
+		log.Printf("Error while unmarshelling minimum balance for rent exemption: %v", err)
+		return 0, err
+	}
+	if result.Error.Message != "" {
+		return 0, errors.New(result.Error.Message)
+	}
+
+	rentCache[size] = rentMinimum{lamports: result.Result, fetchedAt: time.Now()}
+	return result.Result, nil
+}
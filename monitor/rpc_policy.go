@@ -0,0 +1,85 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// defaultRPCTimeout is the timeout of an rpc request if not configured
+const defaultRPCTimeout = 10 * time.Second
+
+// rpcPolicy is the timeout of a request to an rpc method and how often a failed request is retried
+type rpcPolicy struct {
+	timeout time.Duration
+	retries int
+}
+
+var (
+	// defaultPolicy applies to all rpc methods without an override, see ConfigureRPCPolicies
+	defaultPolicy = rpcPolicy{timeout: defaultRPCTimeout}
+	// methodPolicies are the overrides of the default policy keyed by the lowercased rpc method, as viper
+	// lowercases the keys of method_timeouts and method_retries
+	methodPolicies map[string]rpcPolicy
+)
+
+// ConfigureRPCPolicies sets the timeout and retries of rpc requests, globally and per rpc method. Methods without
+// an override, and settings not overridden for a method, use the global ones.
+func ConfigureRPCPolicies(cfg *config.Config) error {
+	e := cfg.Endpoints
+	timeout, err := parseRPCTimeout(e.RPCTimeout, defaultRPCTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid rpc_timeout: %v", err)
+	}
+	def := rpcPolicy{timeout: timeout, retries: e.RPCRetries}
+
+	overrides := make(map[string]rpcPolicy)
+	override := func(method string) rpcPolicy {
+		if p, ok := overrides[method]; ok {
+			return p
+		}
+		return def
+	}
+	for method, s := range e.MethodTimeouts {
+		timeout, err := parseRPCTimeout(s, def.timeout)
+		if err != nil {
+			return fmt.Errorf("invalid method_timeouts of %s: %v", method, err)
+		}
+		p := override(strings.ToLower(method))
+		p.timeout = timeout
+		overrides[strings.ToLower(method)] = p
+	}
+	for method, retries := range e.MethodRetries {
+		p := override(strings.ToLower(method))
+		p.retries = retries
+		overrides[strings.ToLower(method)] = p
+	}
+
+	defaultPolicy, methodPolicies = def, overrides
+	return nil
+}
+
+// parseRPCTimeout returns the given timeout, the fallback if it is empty
+func parseRPCTimeout(s string, fallback time.Duration) (time.Duration, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("timeout %s is not positive", s)
+	}
+	return d, nil
+}
+
+// policyFor returns the timeout and retries of requests to the given rpc method
+func policyFor(method string) rpcPolicy {
+	if p, ok := methodPolicies[strings.ToLower(method)]; ok {
+		return p
+	}
+	return defaultPolicy
+}
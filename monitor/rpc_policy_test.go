@@ -0,0 +1,109 @@
+package monitor_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+func TestConfigureRPCPolicies(t *testing.T) {
+	// a timed out request is still handled while its retry arrives
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	callsOf := func(method string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[method]
+	}
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		calls[req.Method]++
+		n := calls[req.Method]
+		mu.Unlock()
+		// only the first request of each method is slow
+		if n == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","result":1010,"id":1}`))
+	}))
+	defer rpc.Close()
+
+	cfg := &config.Config{Endpoints: config.Endpoints{
+		RPCEndpoint:    rpc.URL,
+		NetworkRPC:     rpc.URL,
+		RPCTimeout:     "5s",
+		MethodTimeouts: map[string]string{"getSlot": "50ms", "getTransactionCount": "50ms"},
+		MethodRetries:  map[string]int{"getTransactionCount": 1},
+	}}
+	if err := monitor.ConfigureRPCPolicies(cfg); err != nil {
+		t.Fatal("Error while configuring rpc policies :", err)
+	}
+	defer monitor.ConfigureRPCPolicies(&config.Config{})
+
+	// the method timeout applies to getSlot, which isn't retried
+	if _, err := monitor.GetCurrentSlot(cfg, utils.Validator); err == nil {
+		t.Error("Expected getSlot to time out after its method timeout")
+	}
+	// the slow first request of getTransactionCount times out and is retried
+	if _, err := monitor.GetTxCount(cfg); err != nil || callsOf("getTransactionCount") != 2 {
+		t.Errorf("Expected getTransactionCount to succeed on retry, got %v after %d calls", err, callsOf("getTransactionCount"))
+	}
+	// other methods use the global timeout
+	if _, err := monitor.GetFirstAvailableBlock(cfg, utils.Validator); err != nil {
+		t.Errorf("Expected getFirstAvailableBlock within the global timeout, got %v", err)
+	}
+
+	cfg.Endpoints.MethodTimeouts["getSlot"] = "fast"
+	if err := monitor.ConfigureRPCPolicies(cfg); err == nil {
+		t.Error("Expected error for invalid method timeout")
+	}
+}
+
+func TestConfigureRPCPoliciesFromTOML(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`{"jsonrpc":"2.0","result":1010,"id":1}`))
+	}))
+	defer rpc.Close()
+
+	// viper lowercases the rpc methods of the keys
+	v := viper.New()
+	v.SetConfigType("toml")
+	err := v.ReadConfig(strings.NewReader(`[rpc_and_lcd_endpoints]
+rpc_endpoint = "` + rpc.URL + `"
+rpc_timeout = "5s"
+method_timeouts = { getSlot = "50ms" }
+`))
+	if err != nil {
+		t.Fatal("Error while reading config :", err)
+	}
+	var cfg config.Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatal("Error while unmarshaling config :", err)
+	}
+	if err := monitor.ConfigureRPCPolicies(&cfg); err != nil {
+		t.Fatal("Error while configuring rpc policies :", err)
+	}
+	defer monitor.ConfigureRPCPolicies(&config.Config{})
+
+	if _, err := monitor.GetCurrentSlot(&cfg, utils.Validator); err == nil {
+		t.Error("Expected getSlot to time out after its method timeout from config.toml")
+	}
+	if _, err := monitor.GetFirstAvailableBlock(&cfg, utils.Validator); err != nil {
+		t.Errorf("Expected getFirstAvailableBlock within the global timeout, got %v", err)
+	}
+}
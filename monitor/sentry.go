@@ -0,0 +1,89 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// defaultSentrySampleRate is the share of errors reported to sentry if not configured. Failing rpc requests
+// recur on every poll, so only a sample of them is needed to debug the monitor.
+const defaultSentrySampleRate = 0.1
+
+// sentryHub reports rpc and parse errors to sentry, nil when no sentry dsn is configured
+var sentryHub *sentry.Hub
+
+// ConfigureSentry reports the errors of rpc requests to sentry when a dsn is configured. The events are sent
+// through the given transport, the default http transport of sentry is used when it is nil.
+func ConfigureSentry(cfg *config.Config, transport sentry.Transport) error {
+	if cfg.Sentry.DSN == "" {
+		sentryHub = nil
+		return nil
+	}
+
+	rate := cfg.Sentry.SampleRate
+	if rate == 0 {
+		rate = defaultSentrySampleRate
+	}
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         cfg.Sentry.DSN,
+		SampleRate:  rate,
+		Environment: cfg.Sentry.Environment,
+		Transport:   transport,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid sentry config: %v", err)
+	}
+	sentryHub = sentry.NewHub(client, sentry.NewScope())
+
+	log.Printf("Reporting %.0f%% of rpc errors to sentry", rate*100)
+	return nil
+}
+
+// FlushSentry waits until the reported errors are sent to sentry or the timeout is reached
+func FlushSentry(timeout time.Duration) {
+	if sentryHub != nil {
+		sentryHub.Flush(timeout)
+	}
+}
+
+// reportRPCError reports the error of an rpc request to sentry with the rpc method and the endpoint host. Errors
+// of the same kind, method and endpoint are grouped into one sentry issue.
+func reportRPCError(ops types.HTTPOptions, kind string, err error) {
+	if sentryHub == nil {
+		return
+	}
+	host := endpointHost(ops.Endpoint)
+	sentryHub.WithScope(func(scope *sentry.Scope) {
+		scope.SetTag("error_type", kind)
+		scope.SetTag("rpc_method", ops.Body.Method)
+		scope.SetTag("endpoint", host)
+		scope.SetFingerprint([]string{kind, ops.Body.Method, host})
+		sentryHub.CaptureException(err)
+	})
+}
+
+// checkRPCResponse reports a response of a json rpc request which can't be parsed or holds an rpc error
+func checkRPCResponse(ops types.HTTPOptions, res *types.PingResp) {
+	if sentryHub == nil || ops.Body.Method == "" {
+		return
+	}
+	var body struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	switch err := json.Unmarshal(res.Body, &body); {
+	case err != nil:
+		reportRPCError(ops, "parse", fmt.Errorf("invalid response of %s with status %d: %v", ops.Body.Method, res.StatusCode, err))
+	case body.Error != nil:
+		reportRPCError(ops, "rpc", fmt.Errorf("%s failed with error %d: %s", ops.Body.Method, body.Error.Code, body.Error.Message))
+	}
+}
@@ -0,0 +1,67 @@
+package monitor_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/monitor"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// sentryTransport records the events instead of sending them to sentry
+type sentryTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *sentryTransport) Flush(timeout time.Duration) bool { return true }
+
+func (t *sentryTransport) Configure(options sentry.ClientOptions) {}
+
+func (t *sentryTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func TestConfigureSentry(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots"},"id":1}`))
+	}))
+	defer rpc.Close()
+
+	cfg := &config.Config{
+		Endpoints: config.Endpoints{RPCEndpoint: rpc.URL, NetworkRPC: rpc.URL},
+		Sentry:    config.Sentry{DSN: "https://key@sentry.example.com/1", SampleRate: 1},
+	}
+	transport := &sentryTransport{}
+	if err := monitor.ConfigureSentry(cfg, transport); err != nil {
+		t.Fatal("Error while configuring sentry :", err)
+	}
+	defer monitor.ConfigureSentry(&config.Config{}, nil)
+
+	monitor.GetCurrentSlot(cfg, utils.Validator)
+
+	// requests which fail in transport are reported as well
+	cfg.Endpoints.RPCEndpoint = "http://127.0.0.1:1"
+	monitor.GetCurrentSlot(cfg, utils.Validator)
+
+	if len(transport.events) != 2 {
+		t.Fatalf("Expected 2 sentry events, got %d", len(transport.events))
+	}
+	for i, kind := range []string{"rpc", "request"} {
+		tags := transport.events[i].Tags
+		if tags["error_type"] != kind || tags["rpc_method"] != "getSlot" {
+			t.Errorf("Expected %s error of getSlot, got tags %v", kind, tags)
+		}
+	}
+	if host := transport.events[0].Tags["endpoint"]; host != rpc.Listener.Addr().String() {
+		t.Errorf("Expected endpoint %s, got %s", rpc.Listener.Addr(), host)
+	}
+}
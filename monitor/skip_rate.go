@@ -2,7 +2,6 @@ package monitor
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"os"
 	"os/exec"
@@ -73,17 +72,9 @@ func SkipRateAlerts(cfg *config.Config) error {
 
 	if valSkipped > netSkipped && (valSkipped > float64(cfg.AlertingThresholds.SkipRateThreshold)) {
 		if strings.EqualFold(cfg.AlerterPreferences.SkipRateAlerts, "yes") {
-			err = alerter.SendTelegramAlert(fmt.Sprintf("SKIP RATE ALERT ::  Your validator SKIP RATE : %f has exceeded network SKIP RATE : %f", valSkipped, netSkipped), cfg)
+			err = alerter.SendAlert(alerter.EventSkipRate, alerter.Localize(cfg, alerter.MsgSkipRate, valSkipped, netSkipped), alerter.Warning, cfg)
 			if err != nil {
-				log.Printf("Error while sending skip rate alert to telegram: %v", err)
-			}
-			err = alerter.SendEmailAlert(fmt.Sprintf("Your validator SKIP RATE : %f has exceeded network SKIP RATE : %f", valSkipped, netSkipped), cfg)
-			if err != nil {
-				log.Printf("Error while sending skip rate alert to email: %v", err)
-			}
-			err = alerter.SendSlackAlert(fmt.Sprintf("SKIP RATE ALERT ::  Your validator SKIP RATE : %f has exceeded network SKIP RATE : %f", valSkipped, netSkipped), cfg)
-			if err != nil {
-				log.Printf("Error while sending skip rate alert to slack: %v", err)
+				log.Printf("Error while sending skip rate alert: %v", err)
 			}
 		}
 	}
@@ -11,11 +11,11 @@ import (
 
 // GetSlotLeader returns the current slot leader
 func GetSlotLeader(cfg *config.Config) (types.SlotLeader, error) {
-	log.Println("Getting Slot Leader...")
+	debugf("Getting Slot Leader...")
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
-		Body:     types.Payload{Jsonrpc: "2.0", Method: "getSlotLeader", ID: 1},
+		Body:     types.Payload{Jsonrpc: "2.0", Method: "getSlotLeader", ID: 1, Params: []interface{}{commitment(cfg)}},
 	}
 
 	var result types.SlotLeader
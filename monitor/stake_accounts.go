@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+	"github.com/Chainflow/solana-mission-control/utils"
+)
+
+// stakeProgramID is the address of the stake program which owns all stake accounts
+const stakeProgramID = "Stake11111111111111111111111111111111111111"
+
+// stakeVoterOffset is the offset of the voter pubkey of the delegation in the data of a stake account
+const stakeVoterOffset = 124
+
+// GetStakeAccounts returns the stake accounts delegated to the vote account of validator
+func GetStakeAccounts(cfg *config.Config, node string) (types.StakeAccounts, error) {
+	debugf("Getting stake accounts...")
+	ops := types.HTTPOptions{
+		Endpoint: cfg.Endpoints.RPCEndpoint,
+		Method:   http.MethodPost,
+		Body: types.Payload{Jsonrpc: "2.0", Method: "getProgramAccounts", ID: 1, Params: []interface{}{
+			stakeProgramID,
+			map[string]interface{}{
+				"encoding":   "jsonParsed",
+				"commitment": commitment(cfg).Commitemnt,
+				"filters": []interface{}{
+					map[string]interface{}{"memcmp": map[string]interface{}{"offset": stakeVoterOffset, "bytes": cfg.ValDetails.VoteKey}},
+				},
+			},
+		}},
+	}
+	if node == utils.Network {
+		ops.Endpoint = cfg.Endpoints.NetworkRPC
+	}
+
+	var result types.StakeAccounts
+	resp, err := HitHTTPTarget(ops)
+	if err != nil {
+		log.Printf("Error while getting stake accounts: %v", err)
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Body, &result)
+	if err != nil {
+		log.Printf("Error while unmarshelling stake accounts: %v", err)
+		return result, err
+	}
+
+	if result.Error.Message != "" {
+		return result, fmt.Errorf("RPC error: %v", result.Error.Message)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Chainflow/solana-mission-control/config"
+	"github.com/Chainflow/solana-mission-control/types"
+)
+
+// telemetryInterval is how often the anonymous usage stats are sent when opted in
+const telemetryInterval = 24 * time.Hour
+
+// genesisClusters maps the genesis hashes of the public clusters to their names, any other cluster is
+// reported as unknown so that private clusters can't be told apart
+var genesisClusters = map[string]string{
+	"5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d": "mainnet-beta",
+	"4uhcVJyU9pJkvQyS88uRDiswHXSCkY3zQawwpjk2NsNY": "testnet",
+	"EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG": "devnet",
+}
+
+// TelemetryReport holds the anonymous usage stats which are sent when opted in. It must never hold keys,
+// names, endpoints or anything else which identifies the validator or the operator.
+type TelemetryReport struct {
+	Features []string `json:"features"`
+	Cluster  string   `json:"cluster"`
+	Version  string   `json:"version"`
+}
+
+// telemetryEnabled reports whether the operator opted in to telemetry. Setting the DO_NOT_TRACK environment
+// variable disables it regardless of the config.
+func telemetryEnabled(cfg *config.Config) bool {
+	return cfg.Telemetry.EnableTelemetry && cfg.Telemetry.Endpoint != "" && os.Getenv("DO_NOT_TRACK") == ""
+}
+
+// WatchTelemetry sends the anonymous usage stats once a day, it returns right away unless opted in
+func WatchTelemetry(cfg *config.Config) {
+	if !telemetryEnabled(cfg) {
+		return
+	}
+	log.Printf("Sending anonymous usage stats (enabled features, cluster and version) once a day, set enable_telemetry = false to stop")
+	for {
+		if err := SendTelemetry(cfg); err != nil {
+			log.Printf("Error while sending usage stats : %v", err)
+		}
+		time.Sleep(telemetryInterval)
+	}
+}
+
+// SendTelemetry posts the anonymous usage stats to the configured telemetry endpoint. Nothing is sent,
+// not even a request to the rpc, unless opted in.
+func SendTelemetry(cfg *config.Config) error {
+	if !telemetryEnabled(cfg) {
+		return nil
+	}
+
+	body, err := json.Marshal(buildTelemetryReport(cfg))
+	if err != nil {
+		return err
+	}
+	httpcli := http.Client{Timeout: 10 * time.Second, Transport: transport}
+	resp, err := httpcli.Post(cfg.Telemetry.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// buildTelemetryReport returns the enabled features, the cluster and the software version of the node
+func buildTelemetryReport(cfg *config.Config) TelemetryReport {
+	report := TelemetryReport{Features: enabledFeatures(cfg), Cluster: "unknown", Version: "unknown"}
+
+	if v, err := GetVersion(cfg); err == nil && v.Result.SolanaCore != "" {
+		report.Version = v.Result.SolanaCore
+	}
+	if hash, err := getGenesisHash(cfg); err == nil {
+		if cluster, ok := genesisClusters[hash]; ok {
+			report.Cluster = cluster
+		}
+	}
+	return report
+}
+
+// enabledFeatures returns the names of the optional features which are enabled in the config
+func enabledFeatures(cfg *config.Config) []string {
+	features := []string{}
+	add := func(name string, enabled bool) {
+		if enabled {
+			features = append(features, name)
+		}
+	}
+	add("telegram_alerts", cfg.EnableAlerts.EnableTelegramAlerts)
+	add("email_alerts", cfg.EnableAlerts.EnableEmailAlerts)
+	add("slack_alerts", cfg.EnableAlerts.EnableSlackAlerts)
+	add("rpc_node", cfg.IsRPCNode())
+	add("price", cfg.Price.EnablePrice)
+	add("statsd", cfg.Prometheus.StatsDAddress != "")
+	add("metrics_file", cfg.Prometheus.MetricsFilePath != "")
+	add("sentry", cfg.Sentry.DSN != "")
+	add("upgrade_readiness", cfg.Upgrade.RequiredVersion != "")
+	add("block_history_file", cfg.Scraper.BlockHistoryFile != "")
+	return features
+}
+
+// getGenesisHash returns the genesis hash of the cluster of the node
+func getGenesisHash(cfg *config.Config) (string, error) {
+	ops := types.HTTPOptions{
+		Endpoint: cfg.Endpoints.RPCEndpoint,
+		Method:   http.MethodPost,
+		Body:     types.Payload{Jsonrpc: "2.0", Method: "getGenesisHash", ID: 1},
+	}
+
+	resp, err := HitHTTPTarget(ops)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return "", err
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,76 @@
+package monitor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestSendTelemetry(t *testing.T) {
+	rpcRequests := 0
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rpcRequests++
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), "getGenesisHash") {
+			w.Write([]byte(`{"jsonrpc":"2.0","result":"5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d","id":1}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","result":{"solana-core":"1.14.17"},"id":1}`))
+	}))
+	defer rpc.Close()
+
+	var reports []string
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		reports = append(reports, string(body))
+	}))
+	defer endpoint.Close()
+
+	cfg := &config.Config{Endpoints: config.Endpoints{RPCEndpoint: rpc.URL, NetworkRPC: rpc.URL}}
+	cfg.ValDetails.PubKey = "valPubKey"
+	cfg.ValDetails.VoteKey = "valVoteKey"
+	cfg.EnableAlerts.EnableSlackAlerts = true
+	cfg.Telemetry.Endpoint = endpoint.URL
+
+	// nothing is sent unless opted in
+	if err := SendTelemetry(cfg); err != nil {
+		t.Fatal("Error while sending telemetry without opt-in :", err)
+	}
+	if len(reports) != 0 || rpcRequests != 0 {
+		t.Fatalf("Expected nothing to be sent without opt-in, got %d reports and %d rpc requests", len(reports), rpcRequests)
+	}
+
+	cfg.Telemetry.EnableTelemetry = true
+	if err := SendTelemetry(cfg); err != nil {
+		t.Fatal("Error while sending telemetry :", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d", len(reports))
+	}
+	for _, secret := range []string{"valPubKey", "valVoteKey", rpc.URL, strings.TrimPrefix(rpc.URL, "http://")} {
+		if strings.Contains(reports[0], secret) {
+			t.Errorf("Expected report without %q, got %s", secret, reports[0])
+		}
+	}
+
+	var report TelemetryReport
+	if err := json.Unmarshal([]byte(reports[0]), &report); err != nil {
+		t.Fatalf("Error while parsing report %s : %v", reports[0], err)
+	}
+	want := TelemetryReport{Features: []string{"slack_alerts"}, Cluster: "mainnet-beta", Version: "1.14.17"}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Expected report %+v, got %+v", want, report)
+	}
+
+	// DO_NOT_TRACK disables it regardless of the config
+	t.Setenv("DO_NOT_TRACK", "1")
+	if err := SendTelemetry(cfg); err != nil || len(reports) != 1 {
+		t.Errorf("Expected nothing to be sent with DO_NOT_TRACK, got %d reports, error %v", len(reports), err)
+	}
+}
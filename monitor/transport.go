@@ -0,0 +1,108 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+// transport is used by the http client of all rpc requests, see ConfigureProxy and ConfigureTestMode
+var transport http.RoundTripper = http.DefaultTransport
+
+// ConfigureProxy routes all rpc requests through the configured proxy, http, https and socks5 proxies
+// are supported. Requests are sent directly when no proxy is configured.
+func ConfigureProxy(cfg *config.Config) error {
+	if cfg.Endpoints.ProxyURL == "" {
+		transport = http.DefaultTransport
+		return nil
+	}
+
+	// the error of url.Parse contains the url, which may contain credentials
+	u, err := url.Parse(cfg.Endpoints.ProxyURL)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("invalid proxy url: %v", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyURL(u)
+	transport = t
+
+	log.Printf("Sending rpc requests through proxy %s", u.Redacted())
+	return nil
+}
+
+// defaultFixturesDir is the directory of the rpc fixtures used in test mode if not configured
+const defaultFixturesDir = "fixtures/rpc"
+
+// ConfigureTestMode answers all rpc requests from the fixtures in the configured directory instead of calling
+// the network. The result of each rpc method is read from <method>.json, methods without a fixture return
+// a method not found error.
+func ConfigureTestMode(cfg *config.Config) error {
+	dir := cfg.TestMode.FixturesDir
+	if dir == "" {
+		dir = defaultFixturesDir
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("test mode fixtures directory %s not found", dir)
+	}
+
+	transport = fixtureTransport{dir: dir}
+	log.Printf("Test mode, answering rpc requests from the fixtures in %s", dir)
+	return nil
+}
+
+// fixtureTransport answers json rpc requests with the result read from the fixture of the method
+type fixtureTransport struct {
+	dir string
+}
+
+// RoundTrip returns the fixture of the requested rpc method wrapped in a json rpc response
+func (t fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var payload struct {
+		Method string `json:"method"`
+		ID     int    `json:"id"`
+	}
+	if req.Body != nil {
+		defer req.Body.Close()
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, fmt.Errorf("invalid rpc request: %v", err)
+		}
+	}
+
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":%d}`, payload.ID)
+	result, err := ioutil.ReadFile(filepath.Join(t.dir, filepath.Base(payload.Method)+".json"))
+	if err == nil {
+		body = fmt.Sprintf(`{"jsonrpc":"2.0","result":%s,"id":%d}`, bytes.TrimSpace(result), payload.ID)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}, nil
+}
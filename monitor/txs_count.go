@@ -19,7 +19,7 @@ func GetTxCount(cfg *config.Config) (types.TxCount, error) {
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
-		Body:     types.Payload{Jsonrpc: "2.0", Method: "getTransactionCount", ID: 1},
+		Body:     types.Payload{Jsonrpc: "2.0", Method: "getTransactionCount", ID: 1, Params: []interface{}{commitment(cfg)}},
 	}
 
 	resp, err := HitHTTPTarget(ops)
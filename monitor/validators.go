@@ -1,8 +1,6 @@
 package monitor
 
 import (
-	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 
@@ -11,16 +9,21 @@ import (
 	"github.com/Chainflow/solana-mission-control/utils"
 )
 
+// voteAccountFields are the fields of the getVoteAccounts result the vote account metrics are derived from
+var voteAccountFields = []string{
+	"current", "delinquent",
+	"current.*.votePubkey", "current.*.nodePubkey", "current.*.activatedStake", "current.*.epochCredits", "current.*.lastVote",
+	"delinquent.*.votePubkey", "delinquent.*.nodePubkey", "delinquent.*.activatedStake", "delinquent.*.epochCredits", "delinquent.*.lastVote",
+}
+
 // GetVoteAccounts returns voting accounts information
 func GetVoteAccounts(cfg *config.Config, node string) (types.GetVoteAccountsResponse, error) {
-	log.Println("Getting Vote Account Information...")
+	debugf("Getting Vote Account Information...")
 	ops := types.HTTPOptions{
 		Endpoint: cfg.Endpoints.RPCEndpoint,
 		Method:   http.MethodPost,
 		Body: types.Payload{Jsonrpc: "2.0", Method: "getVoteAccounts", ID: 1, Params: []interface{}{
-			types.Commitment{
-				Commitemnt: "recent",
-			},
+			commitment(cfg),
 		}},
 	}
 	if node == utils.Network {
@@ -39,15 +42,11 @@ func GetVoteAccounts(cfg *config.Config, node string) (types.GetVoteAccountsResp
 		return result, err
 	}
 
-	err = json.Unmarshal(resp.Body, &result)
+	err = decodeRPCResponse(cfg, ops, resp.Body, &result, voteAccountFields...)
 	if err != nil {
-		log.Printf("Error while unmarshelling leader shedules: %v", err)
+		log.Printf("Error while unmarshelling vote accounts: %v", err)
 		return result, err
 	}
 
-	if result.Error.Code != 0 {
-		return result, fmt.Errorf("RPC error: %d %v", result.Error.Code, result.Error.Message)
-	}
-
 	return result, nil
 }
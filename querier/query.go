@@ -6,6 +6,9 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/Chainflow/solana-mission-control/config"
 	"github.com/Chainflow/solana-mission-control/types"
@@ -38,28 +41,75 @@ func GetAccountBalFromDB(cfg *config.Config) (string, error) {
 	return bal, nil
 }
 
-// AlertStatusCountFromPrometheus returns the AlertCount for validator voting alert
+// defaultAlertCountQuery is the query of validator status alert count if not configured
+const defaultAlertCountQuery = "solana_val_alert_count"
+
+// PrometheusAddresses returns the prometheus_address followed by the configured replicas without duplicates
+func PrometheusAddresses(cfg *config.Config) []string {
+	var addresses []string
+	seen := make(map[string]bool)
+	for _, address := range append([]string{cfg.Prometheus.PrometheusAddress}, cfg.Prometheus.PrometheusReplicas...) {
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// AlertCountQueryURL returns the prometheus query url of the validator status alert count,
+// the query can be overridden in config when the metric is renamed e.g. by relabeling with a prefix
+func AlertCountQueryURL(cfg *config.Config) string {
+	return alertCountQueryURL(cfg, cfg.Prometheus.PrometheusAddress)
+}
+
+// alertCountQueryURL returns the query url of the validator status alert count of the given prometheus
+func alertCountQueryURL(cfg *config.Config, address string) string {
+	query := cfg.Prometheus.AlertCountQuery
+	if query == "" {
+		query = defaultAlertCountQuery
+	}
+	return fmt.Sprintf("%s/api/v1/query?query=%s", address, url.QueryEscape(query))
+}
+
+// AlertStatusCountFromPrometheus returns the AlertCount for validator voting alert. The prometheus replicas are
+// queried in order and the first successful answer is used, so an outage of one replica doesn't break the
+// detection of already sent alerts.
 func AlertStatusCountFromPrometheus(cfg *config.Config) (string, error) {
-	var result types.DBRes
-	var count string
-	response, err := http.Get(fmt.Sprintf("%s/api/v1/query?query=solana_val_alert_count", cfg.Prometheus.PrometheusAddress))
-	if err != nil {
-		log.Printf("Error: %v", err)
-		return count, err
+	err := fmt.Errorf("no prometheus address configured")
+	for _, address := range PrometheusAddresses(cfg) {
+		var count string
+		count, err = alertStatusCount(alertCountQueryURL(cfg, address))
+		if err == nil {
+			return count, nil
+		}
+		log.Printf("Error while querying alert count from prometheus %s : %v", address, err)
 	}
-	responseData, err := ioutil.ReadAll(response.Body)
+	return "", err
+}
+
+// alertStatusCount returns the AlertCount of the given prometheus query url
+func alertStatusCount(queryURL string) (string, error) {
+	client := http.Client{Timeout: 10 * time.Second}
+	response, err := client.Get(queryURL)
 	if err != nil {
-		log.Println(err)
+		return "", err
 	}
-	json.Unmarshal(responseData, &result)
-	if err != nil {
-		log.Printf("Error: %v", err)
-		return count, err
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("query failed with status code: %d", response.StatusCode)
+	}
+	var result types.DBRes
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return "", err
 	}
+
+	var count string
 	if len(result.Data.Result) > 0 {
 		count = result.Data.Result[0].Metric.AlertCount
 	}
-
 	return count, nil
 }
 
@@ -114,3 +164,108 @@ func GetCredits(cfg *config.Config) (string, string, error) {
 
 	return cCredits, pCredits, nil
 }
+
+// CheckPrometheus returns an error if none of the configured prometheus replicas is reachable and ready
+func CheckPrometheus(cfg *config.Config) error {
+	err := fmt.Errorf("no prometheus address configured")
+	for _, address := range PrometheusAddresses(cfg) {
+		if err = checkPrometheus(address); err == nil {
+			return nil
+		}
+		log.Printf("Prometheus at %s is not reachable : %v", address, err)
+	}
+	return err
+}
+
+// checkPrometheus returns an error if the prometheus at the given address is not reachable or not ready
+func checkPrometheus(address string) error {
+	client := http.Client{Timeout: 10 * time.Second}
+	response, err := client.Get(fmt.Sprintf("%s/-/ready", address))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("prometheus at %s is not ready, status code: %d", address, response.StatusCode)
+	}
+	return nil
+}
+
+// QueryValues returns the values of the samples the given prometheus query evaluates to, a single value for a
+// scalar. The prometheus replicas are queried in order and the first successful answer is used.
+func QueryValues(cfg *config.Config, query string) ([]float64, error) {
+	err := fmt.Errorf("no prometheus address configured")
+	for _, address := range PrometheusAddresses(cfg) {
+		var values []float64
+		values, err = queryValues(fmt.Sprintf("%s/api/v1/query?query=%s", address, url.QueryEscape(query)))
+		if err == nil {
+			return values, nil
+		}
+		log.Printf("Error while querying %q from prometheus %s : %v", query, address, err)
+	}
+	return nil, err
+}
+
+// queryValues returns the values of the samples of the given prometheus query url
+func queryValues(queryURL string) ([]float64, error) {
+	client := http.Client{Timeout: 10 * time.Second}
+	response, err := client.Get(queryURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query failed with status code: %d", response.StatusCode)
+	}
+	var result struct {
+		Data struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	// a sample is a pair of timestamp and value, the value is a string to keep NaN and Inf
+	var samples [][]interface{}
+	switch result.Data.ResultType {
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(result.Data.Result, &vector); err != nil {
+			return nil, err
+		}
+		for _, s := range vector {
+			samples = append(samples, s.Value)
+		}
+	case "scalar":
+		var scalar []interface{}
+		if err := json.Unmarshal(result.Data.Result, &scalar); err != nil {
+			return nil, err
+		}
+		samples = append(samples, scalar)
+	default:
+		return nil, fmt.Errorf("unsupported result type %q, the query must return an instant vector or a scalar", result.Data.ResultType)
+	}
+
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		if len(sample) != 2 {
+			return nil, fmt.Errorf("invalid sample %v", sample)
+		}
+		s, ok := sample[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid sample value %v", sample[1])
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,82 @@
+package querier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chainflow/solana-mission-control/config"
+)
+
+func TestAlertCountQueryURL(t *testing.T) {
+	cfg := &config.Config{Prometheus: config.Prometheus{PrometheusAddress: "http://localhost:9090"}}
+	if got, expected := AlertCountQueryURL(cfg), "http://localhost:9090/api/v1/query?query=solana_val_alert_count"; got != expected {
+		t.Errorf("Expected default query url %s, got %s", expected, got)
+	}
+
+	cfg.Prometheus.AlertCountQuery = `mainnet_solana_val_alert_count{job="solana"}`
+	expected := "http://localhost:9090/api/v1/query?query=mainnet_solana_val_alert_count%7Bjob%3D%22solana%22%7D"
+	if got := AlertCountQueryURL(cfg); got != expected {
+		t.Errorf("Expected configured query url %s, got %s", expected, got)
+	}
+}
+
+func TestAlertStatusCountFromPrometheusReplicaDown(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"solana_val_alert_count","alert_count":"true"},"value":[0,"1"]}]}}`))
+	}))
+	defer up.Close()
+
+	// unreachable primary and a failing replica before the healthy one
+	cfg := &config.Config{Prometheus: config.Prometheus{
+		PrometheusAddress:  "http://127.0.0.1:1",
+		PrometheusReplicas: []string{down.URL, up.URL},
+	}}
+
+	count, err := AlertStatusCountFromPrometheus(cfg)
+	if err != nil {
+		t.Fatal("Expected alert count from the healthy replica, got error :", err)
+	}
+	if count != "true" {
+		t.Errorf("Expected alert count true, got %q", count)
+	}
+	if err := CheckPrometheus(cfg); err != nil {
+		t.Error("Expected prometheus check to pass with a healthy replica, got :", err)
+	}
+
+	cfg.Prometheus.PrometheusReplicas = []string{down.URL}
+	if _, err := AlertStatusCountFromPrometheus(cfg); err == nil {
+		t.Error("Expected error when every replica is down")
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	var body string
+	prom := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer prom.Close()
+	cfg := &config.Config{Prometheus: config.Prometheus{PrometheusAddress: prom.URL}}
+
+	body = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"a"},"value":[1700000000,"12"]},{"metric":{"job":"b"},"value":[1700000000,"0.5"]}]}}`
+	values, err := QueryValues(cfg, "solana_val_skip_rate > 0")
+	if err != nil || len(values) != 2 || values[0] != 12 || values[1] != 0.5 {
+		t.Errorf("Expected vector values [12 0.5], got %v, %v", values, err)
+	}
+
+	body = `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"1"]}}`
+	values, err = QueryValues(cfg, "scalar(solana_val_skip_rate) > bool 5")
+	if err != nil || len(values) != 1 || values[0] != 1 {
+		t.Errorf("Expected scalar value [1], got %v, %v", values, err)
+	}
+
+	body = `{"status":"success","data":{"resultType":"matrix","result":[]}}`
+	if _, err := QueryValues(cfg, "solana_val_skip_rate[5m]"); err == nil {
+		t.Error("Expected error for a range vector")
+	}
+}
@@ -129,6 +129,7 @@ type (
 			Code    int    `json:"code"`
 			Message string `json:"message"`
 			Data    struct {
+				NumSlotsBehind int64 `json:"numSlotsBehind"`
 			} `json:"data"`
 		} `json:"error"`
 	}
@@ -197,6 +198,20 @@ type (
 		Result  int64  `json:"result"`
 	}
 
+	// FirstAvailableBlock holds the slot of the lowest confirmed block which has not been purged from the ledger
+	FirstAvailableBlock struct {
+		Jsonrpc string   `json:"jsonrpc"`
+		Result  int64    `json:"result"`
+		Error   rpcError `json:"error"`
+	}
+
+	// MinimumBalance holds the minimum balance in lamports for an account to be rent exempt
+	MinimumBalance struct {
+		Jsonrpc string   `json:"jsonrpc"`
+		Result  int64    `json:"result"`
+		Error   rpcError `json:"error"`
+	}
+
 	// DBRes struct holds the Account balance and alertcount which stored in Database
 	DBRes struct {
 		Status string `json:"status"`
@@ -228,11 +243,14 @@ type (
 	ClustrNode struct {
 		// Jsonrpc string `json:"jsonrpc"`
 		Result []struct {
-			Gossip  string `json:"gossip"`
-			Pubkey  string `json:"pubkey"`
-			RPC     string `json:"rpc"`
-			Tpu     string `json:"tpu"`
-			Version string `json:"version"`
+			Gossip          string `json:"gossip"`
+			Pubkey          string `json:"pubkey"`
+			RPC             string `json:"rpc"`
+			Tpu             string `json:"tpu"`
+			TpuQuic         string `json:"tpuQuic"`
+			TpuForwards     string `json:"tpuForwards"`
+			TpuForwardsQuic string `json:"tpuForwardsQuic"`
+			Version         string `json:"version"`
 		} `json:"result"`
 	}
 
@@ -280,4 +298,76 @@ type (
 			SkippedSlots   int    `json:"skippedSlots"`
 		} `json:"leaders"`
 	}
+
+	// InflationReward holds the inflation rewards of the requested addresses for an epoch,
+	// a result is nil if the address got no reward
+	InflationReward struct {
+		Jsonrpc string `json:"jsonrpc"`
+		Result  []*struct {
+			Epoch         int64 `json:"epoch"`
+			EffectiveSlot int64 `json:"effectiveSlot"`
+			Amount        int64 `json:"amount"`
+			PostBalance   int64 `json:"postBalance"`
+		} `json:"result"`
+		Error rpcError `json:"error"`
+	}
+
+	// StakeAccounts holds the stake accounts returned by getProgramAccounts of the stake program with jsonParsed encoding
+	StakeAccounts struct {
+		Jsonrpc string `json:"jsonrpc"`
+		Result  []struct {
+			Pubkey  string `json:"pubkey"`
+			Account struct {
+				Data struct {
+					Parsed struct {
+						Type string `json:"type"`
+						Info struct {
+							Meta struct {
+								Authorized StakeAuthorized `json:"authorized"`
+							} `json:"meta"`
+							Stake *struct {
+								Delegation StakeDelegation `json:"delegation"`
+							} `json:"stake"`
+						} `json:"info"`
+					} `json:"parsed"`
+				} `json:"data"`
+			} `json:"account"`
+		} `json:"result"`
+		Error rpcError `json:"error"`
+	}
+
+	// MultipleAccounts holds the base64 encoded accounts returned by getMultipleAccounts, an account is nil if it
+	// doesn't exist
+	MultipleAccounts struct {
+		Jsonrpc string `json:"jsonrpc"`
+		Result  struct {
+			Value []*struct {
+				// Data is the base64 encoded data followed by its encoding
+				Data  []string `json:"data"`
+				Owner string   `json:"owner"`
+			} `json:"value"`
+		} `json:"result"`
+		Error rpcError `json:"error"`
+	}
+
+	// StakeDelegation holds the delegation of a stake account, amounts and epochs are u64 encoded as strings
+	StakeDelegation struct {
+		Voter             string `json:"voter"`
+		Stake             string `json:"stake"`
+		ActivationEpoch   string `json:"activationEpoch"`
+		DeactivationEpoch string `json:"deactivationEpoch"`
+	}
+
+	// StakeAuthorized holds the authorities of a stake account
+	StakeAuthorized struct {
+		Staker     string `json:"staker"`
+		Withdrawer string `json:"withdrawer"`
+	}
+
+	// SOLPrice holds the SOL price returned by the price provider in coingecko simple price format
+	SOLPrice struct {
+		Solana struct {
+			USD float64 `json:"usd"`
+		} `json:"solana"`
+	}
 )
@@ -20,6 +20,14 @@ const (
 	Validator = "validator"
 )
 
+// Metric groups whose rpc i.e., Validator or Network can be configured through rpc_sources
+const (
+	VoteAccountsGroup = "vote_accounts"
+	CurrentSlotGroup  = "current_slot"
+	EpochInfoGroup    = "epoch_info"
+	StakeGroup        = "stake"
+)
+
 func roundPrec(x float64, prec int) float64 {
 	if math.IsNaN(x) || math.IsInf(x, 0) {
 		return x
@@ -122,6 +130,21 @@ func FormatNumber(input float64) string {
 
 }
 
+// CompactFormat formats number with a K, M, B or T suffix and the given digits after the decimal point
+// e.g. 1234567 with precision 1 is 1.2M
+func CompactFormat(num float64, precision int) string {
+	units := []string{"", "K", "M", "B", "T"}
+	i := 0
+	for math.Abs(num) >= 999.5 && i < len(units)-1 {
+		num /= 1000
+		i++
+	}
+	if i == 0 {
+		precision = 0
+	}
+	return strconv.FormatFloat(num, 'f', precision, 64) + units[i]
+}
+
 // NearestThousandFormat takes number and converts it to readable format
 func NearestThousandFormat(num float64) string {
 
@@ -147,3 +170,48 @@ func NearestThousandFormat(num float64) string {
 	final := xNumSlice[0] + afterDecimal + xPart
 	return final
 }
+
+// LamportsPerSOL is the number of lamports in one SOL
+const LamportsPerSOL = 1e9
+
+// SOLToLamports converts an amount in SOL e.g. a configured threshold to lamports, to compare it with balances
+// which rpc methods return in lamports
+func SOLToLamports(sol float64) int64 {
+	return int64(math.Round(sol * LamportsPerSOL))
+}
+
+// FormatLamports formats an amount in lamports in the given unit, SOL with the given digits after the decimal
+// point or lamports without, grouping the digits before the decimal point with thousandsSep e.g.
+// 1234567890000 with 2 decimals and "," is 1,234.57 SOL
+func FormatLamports(lamports float64, unit string, decimals int, thousandsSep string) string {
+	if strings.EqualFold(unit, "lamports") {
+		return groupThousands(strconv.FormatFloat(math.Round(lamports), 'f', 0, 64), thousandsSep) + " lamports"
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+	return groupThousands(strconv.FormatFloat(lamports/LamportsPerSOL, 'f', decimals, 64), thousandsSep) + " SOL"
+}
+
+// groupThousands inserts sep between every three digits before the decimal point of the formatted number
+func groupThousands(num, sep string) string {
+	if sep == "" {
+		return num
+	}
+	sign := ""
+	if strings.HasPrefix(num, "-") {
+		sign, num = "-", num[1:]
+	}
+	intPart, fracPart := num, ""
+	if i := strings.Index(num, "."); i >= 0 {
+		intPart, fracPart = num[:i], num[i:]
+	}
+	var b strings.Builder
+	for i, d := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String() + fracPart
+}
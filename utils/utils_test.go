@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestFormatLamports(t *testing.T) {
+	for _, tc := range []struct {
+		lamports float64
+		unit     string
+		decimals int
+		sep      string
+		expected string
+	}{
+		{0, "SOL", 4, ",", "0.0000 SOL"},
+		{0, "SOL", 0, ",", "0 SOL"},
+		{1, "SOL", 9, ",", "0.000000001 SOL"},
+		{5000, "SOL", 4, ",", "0.0000 SOL"},
+		{123456789, "SOL", 4, ",", "0.1235 SOL"},
+		{999999999, "SOL", 2, ",", "1.00 SOL"},
+		{1234567890000, "SOL", 2, ",", "1,234.57 SOL"},
+		{1234567890000, "SOL", 2, "", "1234.57 SOL"},
+		{12345678900000000, "SOL", 0, " ", "12 345 679 SOL"},
+		{-2500000000000, "SOL", 1, ",", "-2,500.0 SOL"},
+		{1234567, "lamports", 4, ",", "1,234,567 lamports"},
+		{0, "lamports", 4, ",", "0 lamports"},
+	} {
+		if got := FormatLamports(tc.lamports, tc.unit, tc.decimals, tc.sep); got != tc.expected {
+			t.Errorf("Expected %v lamports formatted as %q, got %q", tc.lamports, tc.expected, got)
+		}
+	}
+}